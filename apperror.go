@@ -0,0 +1,265 @@
+package utility
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unrolled/render"
+)
+
+// Codes of the errors made by the AppError constructors.
+const (
+	CodeBadRequest      = "bad_request"
+	CodeNotFound        = "not_found"
+	CodeConflict        = "conflict"
+	CodeRateLimited     = "rate_limited"
+	CodeUpstream        = "upstream_error"
+	CodeInternal        = "internal"
+	CodePayloadTooLarge = "payload_too_large"
+)
+
+// ContentProblemJSON is the content type of RFC 7807 problem details.
+const ContentProblemJSON = "application/problem+json"
+
+// AppError is an error to report to API clients. Code, Message, Details and
+// Retryable are rendered by RenderError; Err is the cause, kept for logs.
+type AppError struct {
+	// Code is a stable machine readable code, e.g. "not_found".
+	Code string
+	// Message is a human readable description safe to show to clients.
+	Message string
+	// Status is the HTTP status code.
+	Status int
+	// Details holds extra data for the client, e.g. the invalid fields.
+	Details map[string]interface{}
+	// Retryable tells the client the request may succeed if sent again.
+	Retryable bool
+	Err       error
+
+	retryAfter time.Duration
+}
+
+func (e *AppError) Error() string {
+	msg := e.Code + ": " + e.Message
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the cause.
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// WithDetail returns a copy of e with key set to v in Details.
+func (e *AppError) WithDetail(key string, v interface{}) *AppError {
+	c := *e
+	c.Details = make(map[string]interface{}, len(e.Details)+1)
+	for k, d := range e.Details {
+		c.Details[k] = d
+	}
+	c.Details[key] = v
+	return &c
+}
+
+// WithCause returns a copy of e with err as its cause.
+func (e *AppError) WithCause(err error) *AppError {
+	c := *e
+	c.Err = err
+	return &c
+}
+
+// NewAppError returns an AppError with the given status, code and message.
+func NewAppError(status int, code, message string) *AppError {
+	return &AppError{Code: code, Message: message, Status: status}
+}
+
+// BadRequest returns a 400 AppError.
+func BadRequest(message string) *AppError {
+	return NewAppError(http.StatusBadRequest, CodeBadRequest, message)
+}
+
+// NotFound returns a 404 AppError.
+func NotFound(message string) *AppError {
+	return NewAppError(http.StatusNotFound, CodeNotFound, message)
+}
+
+// Conflict returns a 409 AppError.
+func Conflict(message string) *AppError {
+	return NewAppError(http.StatusConflict, CodeConflict, message)
+}
+
+// RateLimited returns a retryable 429 AppError. A retryAfter above 0 is sent
+// in the Retry-After header and as the retry_after detail, in seconds.
+func RateLimited(message string, retryAfter time.Duration) *AppError {
+	e := NewAppError(http.StatusTooManyRequests, CodeRateLimited, message)
+	e.Retryable = true
+	if retryAfter > 0 {
+		e.retryAfter = retryAfter
+		e = e.WithDetail("retry_after", retryAfterSeconds(retryAfter))
+	}
+	return e
+}
+
+// Upstream returns a retryable 502 AppError for a failed call to another
+// service, with err as the cause.
+func Upstream(message string, err error) *AppError {
+	e := NewAppError(http.StatusBadGateway, CodeUpstream, message)
+	e.Retryable = true
+	e.Err = err
+	return e
+}
+
+// Internal returns a 500 AppError with err as the cause. The message is the
+// generic status text, so the cause is not shown to clients.
+func Internal(err error) *AppError {
+	e := NewAppError(http.StatusInternalServerError, CodeInternal, http.StatusText(http.StatusInternalServerError))
+	e.Err = err
+	return e
+}
+
+func retryAfterSeconds(d time.Duration) int64 {
+	return int64((d + time.Second - 1) / time.Second)
+}
+
+// AsAppError returns the AppError in err's chain. Errors of DecodeBody become
+// a 413 for ErrTooLarge and a 400 otherwise, with a generic message so the
+// types of the server are not shown; any other error, including JSON errors
+// of other sources such as an upstream response, is Internal(err).
+func AsAppError(err error) *AppError {
+	var ae *AppError
+	var je *JSONError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &ae):
+		return ae
+	case errors.As(err, &je) && je.body && errors.Is(je.Err, ErrTooLarge):
+		return NewAppError(http.StatusRequestEntityTooLarge, CodePayloadTooLarge, "Request body too large").WithCause(err)
+	case errors.As(err, &je) && je.body:
+		return BadRequest("Malformed JSON request body").WithCause(err)
+	}
+	return Internal(err)
+}
+
+// errorBody is the JSON envelope RenderError writes.
+type errorBody struct {
+	Error errorPayload `json:"error"`
+}
+
+type errorPayload struct {
+	Code      string                 `json:"code"`
+	Message   string                 `json:"message"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	Retryable bool                   `json:"retryable"`
+	RequestID string                 `json:"request_id,omitempty"`
+}
+
+// problem is an RFC 7807 problem details object, with the AppError fields as
+// extension members.
+type problem struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail,omitempty"`
+	Instance  string                 `json:"instance,omitempty"`
+	Code      string                 `json:"code"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	Retryable bool                   `json:"retryable"`
+	RequestID string                 `json:"request_id,omitempty"`
+}
+
+// RenderError writes err as JSON with the default Service, see Service.RenderError.
+func RenderError(w http.ResponseWriter, err error) {
+	Default().RenderError(w, err)
+}
+
+// RenderErrorFor writes err as JSON for r with the default Service, see
+// Service.RenderErrorFor.
+func RenderErrorFor(w http.ResponseWriter, r *http.Request, err error) {
+	Default().RenderErrorFor(w, r, err)
+}
+
+// RenderError writes err through R as
+//
+//	{"error": {"code": "not_found", "message": "...", "details": {...}, "retryable": false}}
+//
+// with the status of the AppError in err's chain, see AsAppError. With
+// Options.ProblemJSON it writes an RFC 7807 application/problem+json instead.
+// Errors with a 5xx status are logged.
+func (s *Service) RenderError(w http.ResponseWriter, err error) {
+	s.RenderErrorFor(w, nil, err)
+}
+
+// RenderErrorFor is RenderError for a request r, which may be nil. The request
+// ID of r's context is added to the body and the log, and problem+json is
+// written when the Accept header of r asks for it.
+func (s *Service) RenderErrorFor(w http.ResponseWriter, r *http.Request, err error) {
+	ae := AsAppError(err)
+	if ae == nil {
+		return
+	}
+	if ae.Status >= http.StatusInternalServerError {
+		log := s.logger()
+		if r != nil {
+			log = s.LogFrom(r.Context())
+		}
+		log.Error(err.Error(), "code", ae.Code, "status", ae.Status)
+	}
+	s.writeError(w, r, ae)
+}
+
+// writeError renders ae without logging it.
+func (s *Service) writeError(w http.ResponseWriter, r *http.Request, ae *AppError) {
+	status := ae.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	var requestID string
+	if r != nil {
+		requestID = RequestIDFrom(r.Context())
+	}
+	if ae.retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.FormatInt(retryAfterSeconds(ae.retryAfter), 10))
+	}
+
+	if !s.wantsProblem(r) {
+		s.renderer().JSON(w, status, errorBody{Error: errorPayload{
+			Code:      ae.Code,
+			Message:   ae.Message,
+			Details:   ae.Details,
+			Retryable: ae.Retryable,
+			RequestID: requestID,
+		}})
+		return
+	}
+
+	p := problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    ae.Message,
+		Code:      ae.Code,
+		Details:   ae.Details,
+		Retryable: ae.Retryable,
+		RequestID: requestID,
+	}
+	if r != nil {
+		p.Instance = r.URL.Path
+	}
+	s.renderer().Render(w, render.JSON{
+		Head: render.Head{ContentType: ContentProblemJSON + "; charset=UTF-8", Status: status},
+	}, p)
+}
+
+// wantsProblem reports whether to render problem+json for r.
+func (s *Service) wantsProblem(r *http.Request) bool {
+	if s != nil && s.Options.ProblemJSON {
+		return true
+	}
+	return r != nil && strings.Contains(r.Header.Get("Accept"), ContentProblemJSON)
+}
@@ -0,0 +1,183 @@
+package utility
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+)
+
+func TestAppErrorConstructors(t *testing.T) {
+	cause := errors.New("connection refused")
+
+	type want struct {
+		status    int
+		code      string
+		retryable bool
+	}
+
+	testCases := []struct {
+		name string
+		err  *AppError
+		want want
+	}{
+		{
+			name: "Bad Request",
+			err:  BadRequest("target is required"),
+			want: want{
+				status: http.StatusBadRequest,
+				code:   CodeBadRequest,
+			},
+		},
+		{
+			name: "Not Found",
+			err:  NotFound("conversation not found"),
+			want: want{
+				status: http.StatusNotFound,
+				code:   CodeNotFound,
+			},
+		},
+		{
+			name: "Conflict",
+			err:  Conflict("message already sent"),
+			want: want{
+				status: http.StatusConflict,
+				code:   CodeConflict,
+			},
+		},
+		{
+			name: "Rate Limited",
+			err:  RateLimited("slow down", 1500*time.Millisecond),
+			want: want{
+				status:    http.StatusTooManyRequests,
+				code:      CodeRateLimited,
+				retryable: true,
+			},
+		},
+		{
+			name: "Upstream",
+			err:  Upstream("carrier unavailable", cause),
+			want: want{
+				status:    http.StatusBadGateway,
+				code:      CodeUpstream,
+				retryable: true,
+			},
+		},
+		{
+			name: "Internal",
+			err:  Internal(cause),
+			want: want{
+				status: http.StatusInternalServerError,
+				code:   CodeInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want.status, tc.err.Status)
+			assert.Equal(t, tc.want.code, tc.err.Code)
+			assert.Equal(t, tc.want.retryable, tc.err.Retryable)
+		})
+	}
+
+	err := Wrap(Upstream("carrier unavailable", cause), "sending")
+	assert.True(t, errors.Is(err, cause))
+	assert.Equal(t, "sending: upstream_error: carrier unavailable: connection refused", err.Error())
+
+	withDetail := NotFound("x").WithDetail("id", 7)
+	assert.Equal(t, map[string]interface{}{"id": 7}, withDetail.Details)
+	assert.Equal(t, map[string]interface{}{"retry_after": int64(2)}, RateLimited("slow down", 1500*time.Millisecond).Details)
+}
+
+func TestAsAppError(t *testing.T) {
+	assert.Nil(t, AsAppError(nil))
+
+	nf := NotFound("missing")
+	assert.Equal(t, nf, AsAppError(WithStack(nf)))
+
+	var v struct {
+		Count int `json:"count"`
+	}
+	ae := AsAppError(DecodeBody(newTestBody(`{"count":"x"}`), &v, 0))
+	assert.Equal(t, http.StatusBadRequest, ae.Status)
+	assert.Equal(t, "Malformed JSON request body", ae.Message)
+
+	ae = AsAppError(Wrap(DecodeBody(newTestBody(`{"count":`), &v, 0), "reading webhook"))
+	assert.Equal(t, http.StatusBadRequest, ae.Status)
+
+	ae = AsAppError(DecodeBody(newTestBody(`{"count":1}`), &v, 2))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, ae.Status)
+
+	ae = AsAppError(Wrap(UnmarshalE([]byte(`{"a":`), &v), "carrier response"))
+	assert.Equal(t, http.StatusInternalServerError, ae.Status)
+	ae = AsAppError(UnmarshalE([]byte(`{}`), &v, MaxSize(1)))
+	assert.Equal(t, http.StatusInternalServerError, ae.Status)
+
+	ae = AsAppError(errors.New("db password is hunter2"))
+	assert.Equal(t, http.StatusInternalServerError, ae.Status)
+	assert.Equal(t, "Internal Server Error", ae.Message)
+}
+
+func TestRenderError(t *testing.T) {
+	rec := NewRecordLogger()
+	s := &Service{Logger: rec, R: render.New()}
+
+	w := httptest.NewRecorder()
+	s.RenderError(w, NotFound("conversation not found").WithDetail("target", "fb:1:2"))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	assert.JSONEq(t, `{"error":{"code":"not_found","message":"conversation not found","details":{"target":"fb:1:2"},"retryable":false}}`, w.Body.String())
+	assert.Empty(t, rec.Records())
+
+	w = httptest.NewRecorder()
+	s.RenderError(w, RateLimited("slow down", 30*time.Second))
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error":{"code":"rate_limited","message":"slow down","details":{"retry_after":30},"retryable":true}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	s.RenderError(w, errors.New("db password is hunter2"))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "hunter2")
+	assert.Len(t, rec.Records(), 1)
+	assert.Equal(t, "db password is hunter2", rec.Records()[0].Msg)
+}
+
+func TestRenderErrorProblem(t *testing.T) {
+	s := &Service{Logger: NewRecordLogger(), R: render.New()}
+
+	r := httptest.NewRequest(http.MethodGet, "/conversations/42", nil)
+	r.Header.Set("Accept", "application/problem+json, application/json")
+	r = r.WithContext(WithRequestID(context.Background(), "req-7"))
+
+	w := httptest.NewRecorder()
+	s.RenderErrorFor(w, r, Conflict("message already sent"))
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "application/problem+json; charset=UTF-8", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Conflict",
+		"status": 409,
+		"detail": "message already sent",
+		"instance": "/conversations/42",
+		"code": "conflict",
+		"retryable": false,
+		"request_id": "req-7"
+	}`, w.Body.String())
+
+	s.Options.ProblemJSON = true
+	w = httptest.NewRecorder()
+	s.RenderError(w, BadRequest("target is required"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/problem+json; charset=UTF-8", w.Header().Get("Content-Type"))
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	(&Service{R: render.New()}).RenderErrorFor(w, r.WithContext(WithRequestID(r.Context(), "req-8")), NotFound("gone"))
+	assert.JSONEq(t, `{"error":{"code":"not_found","message":"gone","retryable":false,"request_id":"req-8"}}`, w.Body.String())
+}
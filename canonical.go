@@ -0,0 +1,209 @@
+package utility
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// CanonicalJSON returns the RFC 8785 (JCS) canonical JSON encoding of v:
+// object keys sorted by their UTF-16 code units, numbers formatted like
+// ECMAScript does, strings with minimal escaping and no white space. Unlike
+// JCS, an integer a float64 can't hold exactly, such as a Facebook ID above
+// 2^53, is written with all its digits, so distinct IDs stay distinct. v is
+// first encoded with MarshalE, so struct tags and json.Marshaler are honored;
+// a []byte or json.RawMessage is taken as JSON already.
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	var raw []byte
+	switch b := v.(type) {
+	case json.RawMessage:
+		raw = b
+	case []byte:
+		raw = b
+	default:
+		var err error
+		if raw, err = MarshalE(v); err != nil {
+			return nil, err
+		}
+	}
+
+	var doc interface{}
+	if err := UnmarshalE(raw, &doc, UseNumber()); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, doc); err != nil {
+		return nil, &JSONError{Op: "marshal", Err: err}
+	}
+	return buf.Bytes(), nil
+}
+
+// PayloadHash returns the hex encoded SHA-256 digest of the canonical JSON
+// of v. Payloads that differ only in key order, white space or number
+// notation hash the same.
+func PayloadHash(v interface{}) (string, error) {
+	data, err := CanonicalJSON(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// exactInteger returns the decimal digits of n if it is an integer literal
+// that a float64 can't represent exactly.
+func exactInteger(n json.Number) (string, bool) {
+	if strings.ContainsAny(string(n), ".eE") {
+		return "", false
+	}
+	i, ok := new(big.Int).SetString(string(n), 10)
+	if !ok {
+		return "", false
+	}
+	if _, acc := new(big.Float).SetInt(i).Float64(); acc == big.Exact {
+		return "", false
+	}
+	return i.String(), true
+}
+
+func writeCanonical(buf *bytes.Buffer, v interface{}) error {
+	switch c := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(c))
+	case json.Number:
+		if digits, ok := exactInteger(c); ok {
+			buf.WriteString(digits)
+			break
+		}
+		f, err := c.Float64()
+		if err != nil {
+			return err
+		}
+		buf.WriteString(formatESNumber(f))
+	case string:
+		writeCanonicalString(buf, c)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, e := range c {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, e); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(c))
+		for k := range c {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return lessUTF16(keys[i], keys[j]) })
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, k)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, c[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return ErrWrongType
+	}
+	return nil
+}
+
+// lessUTF16 compares two strings by their UTF-16 code units, as JCS sorts keys.
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
+
+// writeCanonicalString escapes only what JSON requires: quotes, backslashes
+// and control characters.
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	const hexDigits = "0123456789abcdef"
+	buf.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if c < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hexDigits[c>>4])
+				buf.WriteByte(hexDigits[c&0xf])
+				continue
+			}
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// formatESNumber formats f like ECMAScript's Number.prototype.toString: the
+// shortest digits that round-trip, in plain notation for exponents from -7
+// to 20 and in exponent notation otherwise.
+func formatESNumber(f float64) string {
+	if f == 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+		// JSON has no NaN or Infinity, and -0 is written as 0.
+		return "0"
+	}
+	sign := ""
+	if f < 0 {
+		sign, f = "-", -f
+	}
+
+	// 'e' formatting gives "d.ddde±xx" with the shortest round-trip digits.
+	mantissa, exp, _ := strings.Cut(strconv.FormatFloat(f, 'e', -1, 64), "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	e, _ := strconv.Atoi(exp)
+	k, n := len(digits), e+1
+
+	switch {
+	case k <= n && n <= 21:
+		return sign + digits + strings.Repeat("0", n-k)
+	case 0 < n && n <= 21:
+		return sign + digits[:n] + "." + digits[n:]
+	case -6 < n && n <= 0:
+		return sign + "0." + strings.Repeat("0", -n) + digits
+	}
+	out := sign + digits[:1]
+	if k > 1 {
+		out += "." + digits[1:]
+	}
+	if n-1 >= 0 {
+		return out + "e+" + strconv.Itoa(n-1)
+	}
+	return out + "e" + strconv.Itoa(n-1)
+}
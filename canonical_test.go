@@ -0,0 +1,114 @@
+package utility
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	type args struct {
+		v interface{}
+	}
+	type want struct {
+		output string
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "RFC 8785 Example",
+			args: args{
+				v: []byte(`{
+					"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+					"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+					"literals": [null, true, false]
+				}`),
+			},
+			want: want{
+				output: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+			},
+		},
+		{
+			name: "UTF-16 Key Order",
+			args: args{
+				v: json.RawMessage(`{"\u20ac":1,"\r":2,"\ufb33":3,"1":4,"\ud83d\ude00":5,"\u0080":6,"\u00f6":7}`),
+			},
+			want: want{
+				output: "{\"\\r\":2,\"1\":4,\"\u0080\":6,\"ö\":7,\"€\":1,\"😀\":5,\"\ufb33\":3}",
+			},
+		},
+		{
+			name: "Numbers",
+			args: args{
+				v: []interface{}{0, -0.0, 1e20, 1e21, 1e-6, 1e-7, -1.5, 100, 9007199254740993},
+			},
+			want: want{
+				output: `[0,0,100000000000000000000,1e+21,0.000001,1e-7,-1.5,100,9007199254740993]`,
+			},
+		},
+		{
+			name: "Large Integers Keep Their Digits",
+			args: args{
+				v: []byte(`[9007199254740992, 9007199254740993, -18014398509481985, 123456789012345678901234567890]`),
+			},
+			want: want{
+				output: `[9007199254740992,9007199254740993,-18014398509481985,123456789012345678901234567890]`,
+			},
+		},
+		{
+			name: "Struct",
+			args: args{
+				v: struct {
+					Z string `json:"z"`
+					A string `json:"a"`
+				}{Z: "<b>", A: "x"},
+			},
+			want: want{
+				output: `{"a":"x","z":"<b>"}`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := CanonicalJSON(tc.args.v)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want.output, string(output))
+		})
+	}
+
+	_, err := CanonicalJSON([]byte(`{"a":`))
+	assert.Error(t, err)
+}
+
+func TestPayloadHash(t *testing.T) {
+	type event struct {
+		ID     string  `json:"id"`
+		Amount float64 `json:"amount"`
+	}
+
+	h1, err := PayloadHash(event{ID: "evt_1", Amount: 10})
+	assert.NoError(t, err)
+	h2, err := PayloadHash(map[string]interface{}{"amount": 10.0, "id": "evt_1"})
+	assert.NoError(t, err)
+	h3, err := PayloadHash([]byte(`{ "id" : "evt_1", "amount" : 1.0E1 }`))
+	assert.NoError(t, err)
+	assert.Equal(t, h1, h2)
+	assert.Equal(t, h1, h3)
+	assert.Len(t, h1, 64)
+
+	h4, err := PayloadHash(event{ID: "evt_2", Amount: 10})
+	assert.NoError(t, err)
+	assert.NotEqual(t, h1, h4)
+
+	h5, err := PayloadHash([]byte(`{"id":9007199254740993}`))
+	assert.NoError(t, err)
+	h6, err := PayloadHash([]byte(`{"id":9007199254740992}`))
+	assert.NoError(t, err)
+	assert.NotEqual(t, h5, h6)
+}
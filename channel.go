@@ -0,0 +1,153 @@
+package utility
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Capability is a bit set of features a Channel supports.
+type Capability uint
+
+const (
+	// CapText the channel can deliver plain text messages.
+	CapText Capability = 1 << iota
+	// CapMedia the channel can deliver images and attachments.
+	CapMedia
+	// CapRichContent the channel supports cards, quick replies and other rich layouts.
+	CapRichContent
+	// CapTemplates the channel requires or supports pre-approved message templates.
+	CapTemplates
+	// CapReadReceipts the channel reports when a message was read.
+	CapReadReceipts
+	// CapTypingIndicator the channel can show a typing indicator.
+	CapTypingIndicator
+)
+
+// Has reports whether every capability in c is present.
+func (cp Capability) Has(c Capability) bool {
+	return cp&c == c
+}
+
+// Channel describes a messaging channel that a Target can be routed through.
+type Channel struct {
+	// Prefix is the first ":" separated segment of a Target, e.g. "fb".
+	Prefix string
+	// Name is the display name returned by Origin.
+	Name string
+	// ShowPhone reports whether the customer phone number may be shown on this channel.
+	ShowPhone bool
+	// Capabilities the channel supports.
+	Capabilities Capability
+}
+
+var (
+	// ErrChannelPrefix is returned when registering a Channel with a blank or malformed prefix.
+	ErrChannelPrefix = errors.New("utility: channel prefix must be non-empty and must not contain \":\"")
+	// ErrChannelExists is returned when registering a Channel whose prefix is already taken.
+	ErrChannelExists = errors.New("utility: channel already registered")
+)
+
+// SMSChannel is the channel used for any Target without a registered prefix.
+var SMSChannel = Channel{
+	Name:         "SMS",
+	ShowPhone:    true,
+	Capabilities: CapText | CapMedia,
+}
+
+var channels = struct {
+	sync.RWMutex
+	m map[string]Channel
+}{
+	m: map[string]Channel{
+		TargetHeymarketPrefix: {
+			Prefix:       TargetHeymarketPrefix,
+			Name:         "Heymarket",
+			Capabilities: CapText | CapMedia | CapReadReceipts | CapTypingIndicator,
+		},
+		TargetFacebookPrefix: {
+			Prefix:       TargetFacebookPrefix,
+			Name:         "Facebook",
+			Capabilities: CapText | CapMedia | CapRichContent | CapReadReceipts | CapTypingIndicator,
+		},
+		TargetLinePrefix: {
+			Prefix:       TargetLinePrefix,
+			Name:         "Line",
+			Capabilities: CapText | CapMedia | CapRichContent | CapReadReceipts,
+		},
+		TargetAbcPrefix: {
+			Prefix:       TargetAbcPrefix,
+			Name:         "Apple Business Chat",
+			Capabilities: CapText | CapMedia | CapRichContent | CapReadReceipts | CapTypingIndicator,
+		},
+		TargetGmbPrefix: {
+			Prefix:       TargetGmbPrefix,
+			Name:         "Google",
+			Capabilities: CapText | CapMedia | CapRichContent,
+		},
+		// WhatsApp identifies customers by their phone number, which the
+		// business already sees, so like SMS the number is not hidden.
+		TargetWhatsAppPrefix: {
+			Prefix:       TargetWhatsAppPrefix,
+			Name:         "WhatsApp",
+			ShowPhone:    true,
+			Capabilities: CapText | CapMedia | CapTemplates | CapReadReceipts,
+		},
+	},
+}
+
+// RegisterChannel adds c to the channel registry so Origin, ShowPhone and
+// ChannelFor recognise Targets starting with c.Prefix. It is safe to call
+// from an init function of another package.
+func RegisterChannel(c Channel) error {
+	if c.Prefix == "" || strings.Contains(c.Prefix, ":") {
+		return ErrChannelPrefix
+	}
+	channels.Lock()
+	defer channels.Unlock()
+	if _, ok := channels.m[c.Prefix]; ok {
+		return ErrChannelExists
+	}
+	channels.m[c.Prefix] = c
+	return nil
+}
+
+// MustRegisterChannel is like RegisterChannel but panics on error.
+func MustRegisterChannel(c Channel) {
+	if e := RegisterChannel(c); e != nil {
+		panic(e)
+	}
+}
+
+// LookupChannel returns the Channel registered for prefix.
+func LookupChannel(prefix string) (Channel, bool) {
+	channels.RLock()
+	defer channels.RUnlock()
+	c, ok := channels.m[prefix]
+	return c, ok
+}
+
+// Channels returns every registered Channel sorted by prefix.
+func Channels() []Channel {
+	channels.RLock()
+	list := make([]Channel, 0, len(channels.m))
+	for _, c := range channels.m {
+		list = append(list, c)
+	}
+	channels.RUnlock()
+	sort.Slice(list, func(i, j int) bool { return list[i].Prefix < list[j].Prefix })
+	return list
+}
+
+// ChannelFor returns the Channel the target is routed through, SMSChannel if its prefix is not registered.
+func ChannelFor(target string) Channel {
+	prefix := target
+	if i := strings.Index(target, ":"); i >= 0 {
+		prefix = target[:i]
+	}
+	if c, ok := LookupChannel(prefix); ok {
+		return c
+	}
+	return SMSChannel
+}
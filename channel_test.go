@@ -0,0 +1,156 @@
+package utility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterChannel(t *testing.T) {
+	type args struct {
+		channel Channel
+	}
+	type want struct {
+		err error
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "New Channel",
+			args: args{
+				channel: Channel{Prefix: "ig", Name: "Instagram", Capabilities: CapText | CapMedia},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "Already Registered",
+			args: args{
+				channel: Channel{Prefix: TargetFacebookPrefix, Name: "Another Facebook"},
+			},
+			want: want{
+				err: ErrChannelExists,
+			},
+		},
+		{
+			name: "Blank Prefix",
+			args: args{
+				channel: Channel{Name: "Nothing"},
+			},
+			want: want{
+				err: ErrChannelPrefix,
+			},
+		},
+		{
+			name: "Prefix With Separator",
+			args: args{
+				channel: Channel{Prefix: "tg:bot", Name: "Telegram"},
+			},
+			want: want{
+				err: ErrChannelPrefix,
+			},
+		},
+	}
+
+	defer func() {
+		channels.Lock()
+		delete(channels.m, "ig")
+		channels.Unlock()
+	}()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := RegisterChannel(tc.args.channel)
+			assert.Equal(t, tc.want.err, err)
+		})
+	}
+
+	assert.Equal(t, "Instagram", Origin("ig:1234:5678"))
+	assert.False(t, ShowPhone("ig:1234:5678"))
+	assert.Equal(t, "Facebook", Origin("fb:1234"))
+}
+
+func TestChannelFor(t *testing.T) {
+	type args struct {
+		target string
+	}
+	type want struct {
+		prefix    string
+		showPhone bool
+		canRich   bool
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Facebook",
+			args: args{
+				target: "fb:page:user",
+			},
+			want: want{
+				prefix:    TargetFacebookPrefix,
+				showPhone: false,
+				canRich:   true,
+			},
+		},
+		{
+			name: "WhatsApp",
+			args: args{
+				target: "whatsapp:+19700000987",
+			},
+			want: want{
+				prefix:    TargetWhatsAppPrefix,
+				showPhone: true,
+				canRich:   false,
+			},
+		},
+		{
+			name: "Prefix Only",
+			args: args{
+				target: "gmb",
+			},
+			want: want{
+				prefix:    TargetGmbPrefix,
+				showPhone: false,
+				canRich:   true,
+			},
+		},
+		{
+			name: "Phone Number Falls Back To SMS",
+			args: args{
+				target: "19700000987",
+			},
+			want: want{
+				prefix:    "",
+				showPhone: true,
+				canRich:   false,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := ChannelFor(tc.args.target)
+			assert.Equal(t, tc.want.prefix, c.Prefix)
+			assert.Equal(t, tc.want.showPhone, c.ShowPhone)
+			assert.Equal(t, tc.want.canRich, c.Capabilities.Has(CapRichContent))
+		})
+	}
+}
+
+func TestChannels(t *testing.T) {
+	list := Channels()
+	for i := 1; i < len(list); i++ {
+		assert.True(t, list[i-1].Prefix < list[i].Prefix)
+	}
+	_, ok := LookupChannel(TargetLinePrefix)
+	assert.True(t, ok)
+}
@@ -0,0 +1,331 @@
+package utility
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrBlank is returned when converting nil or an empty string.
+	ErrBlank = errors.New("blank value")
+	// ErrWrongType is returned when a value's type can't be converted.
+	ErrWrongType = errors.New("wrong type")
+	// ErrSyntax is returned when a string is not in the expected format.
+	ErrSyntax = errors.New("invalid syntax")
+	// ErrOverflow is returned when a value does not fit the target type.
+	ErrOverflow = errors.New("value out of range")
+	// ErrTruncated is returned when converting a number with a fractional part to an integer.
+	ErrTruncated = errors.New("value would be truncated")
+)
+
+// ConvError reports a failed conversion.
+type ConvError struct {
+	Func  string // the function that failed, e.g. "ParseInt64"
+	Value interface{}
+	Err   error
+}
+
+func (e *ConvError) Error() string {
+	return fmt.Sprintf("utility.%s: converting %T(%v): %v", e.Func, e.Value, e.Value, e.Err)
+}
+
+// Unwrap returns the underlying error, one of the Err* values or a decoding error.
+func (e *ConvError) Unwrap() error {
+	return e.Err
+}
+
+func convError(fn string, v interface{}, err error) error {
+	return &ConvError{Func: fn, Value: v, Err: err}
+}
+
+// numError maps strconv errors to ErrSyntax and ErrOverflow.
+func numError(err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return ErrOverflow
+	}
+	return ErrSyntax
+}
+
+// ParseInt64 parses a base 10 integer.
+func ParseInt64(v string) (int64, error) {
+	s := strings.TrimSpace(v)
+	if s == "" {
+		return 0, convError("ParseInt64", v, ErrBlank)
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, convError("ParseInt64", v, numError(err))
+	}
+	return n, nil
+}
+
+// ParseInt parses a base 10 integer that fits an int.
+func ParseInt(v string) (int, error) {
+	n, err := ParseInt64(v)
+	if err != nil {
+		err.(*ConvError).Func = "ParseInt"
+		return 0, err
+	}
+	if n < math.MinInt || n > math.MaxInt {
+		return 0, convError("ParseInt", v, ErrOverflow)
+	}
+	return int(n), nil
+}
+
+// AsFloat64 returns v as a float64. It accepts every integer and float kind,
+// json.Number, numeric strings, bools (1 or 0) and pointers to any of those.
+func AsFloat64(v interface{}) (float64, error) {
+	f, err := toFloat64(v)
+	if err != nil {
+		return 0, convError("AsFloat64", v, err)
+	}
+	return f, nil
+}
+
+// AsInt64 returns v as an int64. It accepts the same values as AsFloat64;
+// floats and numeric strings must be whole numbers within range.
+func AsInt64(v interface{}) (int64, error) {
+	n, err := toInt64(v, false)
+	if err != nil {
+		return 0, convError("AsInt64", v, err)
+	}
+	return n, nil
+}
+
+// AsInt returns v as an int. It accepts the same values as AsInt64.
+func AsInt(v interface{}) (int, error) {
+	n, err := toInt64(v, false)
+	if err == nil && (n < math.MinInt || n > math.MaxInt) {
+		err = ErrOverflow
+	}
+	if err != nil {
+		return 0, convError("AsInt", v, err)
+	}
+	return int(n), nil
+}
+
+// FloatAsInt returns v as an int, truncating any fractional part towards zero.
+func FloatAsInt(v interface{}) (int, error) {
+	n, err := toInt64(v, true)
+	if err == nil && (n < math.MinInt || n > math.MaxInt) {
+		err = ErrOverflow
+	}
+	if err != nil {
+		return 0, convError("FloatAsInt", v, err)
+	}
+	return int(n), nil
+}
+
+// toFloat64 converts the numeric value v, returning a bare Err* value on failure.
+func toFloat64(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case nil:
+		return 0, ErrBlank
+	case float64:
+		return n, nil
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case json.Number:
+		return parseFloat(string(n))
+	case string:
+		return parseFloat(n)
+	}
+
+	rv, err := indirectValue(v)
+	if err != nil {
+		return 0, err
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Bool:
+		if rv.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.String:
+		return parseFloat(rv.String())
+	}
+	return 0, ErrWrongType
+}
+
+// toInt64 converts the numeric value v, returning a bare Err* value on failure.
+// Fractional parts are an ErrTruncated error unless truncate is set.
+func toInt64(v interface{}, truncate bool) (int64, error) {
+	switch n := v.(type) {
+	case nil:
+		return 0, ErrBlank
+	case int:
+		return int64(n), nil
+	case int64:
+		return n, nil
+	case float64:
+		return floatToInt64(n, truncate)
+	case json.Number:
+		return parseInt(string(n), truncate)
+	case string:
+		return parseInt(n, truncate)
+	}
+
+	rv, err := indirectValue(v)
+	if err != nil {
+		return 0, err
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, ErrOverflow
+		}
+		return int64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return floatToInt64(rv.Float(), truncate)
+	case reflect.Bool:
+		if rv.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.String:
+		return parseInt(rv.String(), truncate)
+	}
+	return 0, ErrWrongType
+}
+
+// indirectValue follows pointers and interfaces down to the value v refers to.
+func indirectValue(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Value{}, ErrBlank
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return reflect.Value{}, ErrBlank
+	}
+	return rv, nil
+}
+
+func parseFloat(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrBlank
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, numError(err)
+	}
+	return f, nil
+}
+
+func parseInt(s string, truncate bool) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrBlank
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err == nil {
+		return n, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, ErrOverflow
+	}
+	// "12.0" and "1e3" are whole numbers written as floats.
+	f, ferr := strconv.ParseFloat(s, 64)
+	if ferr != nil {
+		return 0, numError(ferr)
+	}
+	return floatToInt64(f, truncate)
+}
+
+func floatToInt64(f float64, truncate bool) (int64, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, ErrOverflow
+	}
+	if !truncate && f != math.Trunc(f) {
+		return 0, ErrTruncated
+	}
+	return int64(f), nil
+}
+
+// AsMap returns v as a map[string]interface{}.
+func AsMap(v interface{}) (map[string]interface{}, error) {
+	switch m := v.(type) {
+	case nil:
+		return nil, convError("AsMap", v, ErrBlank)
+	case map[string]interface{}:
+		return m, nil
+	case Prop:
+		return m, nil
+	}
+	return nil, convError("AsMap", v, ErrWrongType)
+}
+
+// ParseJSONMap decodes the JSON object in v, which can be a json.RawMessage,
+// *json.RawMessage, []byte, string or io.Reader. Pass UseNumber to keep
+// numbers as json.Number, so large IDs don't lose precision in a float64.
+func ParseJSONMap(v interface{}, opts ...JSONOption) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	if err := parseJSON("ParseJSONMap", v, &m, opts); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ParseJSONSlice decodes the JSON array in v, which can be any of the types
+// ParseJSONMap accepts.
+func ParseJSONSlice(v interface{}, opts ...JSONOption) ([]interface{}, error) {
+	s := []interface{}{}
+	if err := parseJSON("ParseJSONSlice", v, &s, opts); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// parseJSON decodes the raw JSON in v into dst.
+func parseJSON(fn string, v interface{}, dst interface{}, opts []JSONOption) error {
+	var raw []byte
+	switch b := v.(type) {
+	case nil:
+		return convError(fn, v, ErrBlank)
+	case json.RawMessage:
+		raw = b
+	case *json.RawMessage:
+		if b == nil {
+			return convError(fn, v, ErrBlank)
+		}
+		raw = *b
+	case []byte:
+		raw = b
+	case string:
+		raw = []byte(b)
+	case io.Reader:
+		if err := decodeJSON(newJSONOptions(opts).decoder(b), dst); err != nil {
+			return convError(fn, v, err)
+		}
+		return nil
+	default:
+		return convError(fn, v, ErrWrongType)
+	}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return convError(fn, v, ErrBlank)
+	}
+	if err := UnmarshalE(raw, dst, opts...); err != nil {
+		return convError(fn, v, err)
+	}
+	return nil
+}
@@ -0,0 +1,469 @@
+package utility
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInt64(t *testing.T) {
+	type args struct {
+		input string
+	}
+	type want struct {
+		output int64
+		err    error
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "right string with int",
+			args: args{
+				input: "100",
+			},
+			want: want{
+				output: 100,
+			},
+		},
+		{
+			name: "padded negative",
+			args: args{
+				input: " -42 ",
+			},
+			want: want{
+				output: -42,
+			},
+		},
+		{
+			name: "blank string",
+			args: args{
+				input: "",
+			},
+			want: want{
+				err: ErrBlank,
+			},
+		},
+		{
+			name: "string with non numeric",
+			args: args{
+				input: "xyz1000",
+			},
+			want: want{
+				err: ErrSyntax,
+			},
+		},
+		{
+			name: "too big",
+			args: args{
+				input: "92233720368547758070",
+			},
+			want: want{
+				err: ErrOverflow,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := ParseInt64(tc.args.input)
+			assert.True(t, errors.Is(err, tc.want.err), "got error %v", err)
+			assert.Equal(t, tc.want.output, output)
+
+			i, err := ParseInt(tc.args.input)
+			assert.True(t, errors.Is(err, tc.want.err), "got error %v", err)
+			assert.Equal(t, int(tc.want.output), i)
+		})
+	}
+}
+
+func TestAsFloat64(t *testing.T) {
+	type args struct {
+		v interface{}
+	}
+	type want struct {
+		output float64
+		err    error
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Float64",
+			args: args{
+				v: 89.5,
+			},
+			want: want{
+				output: 89.5,
+			},
+		},
+		{
+			name: "Nil",
+			args: args{
+				v: nil,
+			},
+			want: want{
+				err: ErrBlank,
+			},
+		},
+		{
+			name: "Int",
+			args: args{
+				v: 89,
+			},
+			want: want{
+				output: 89,
+			},
+		},
+		{
+			name: "Uint32",
+			args: args{
+				v: uint32(89),
+			},
+			want: want{
+				output: 89,
+			},
+		},
+		{
+			name: "JSON Number",
+			args: args{
+				v: json.Number("89.5"),
+			},
+			want: want{
+				output: 89.5,
+			},
+		},
+		{
+			name: "String",
+			args: args{
+				v: "-1e3",
+			},
+			want: want{
+				output: -1000,
+			},
+		},
+		{
+			name: "Bad String",
+			args: args{
+				v: "89.5.1",
+			},
+			want: want{
+				err: ErrSyntax,
+			},
+		},
+		{
+			name: "Struct",
+			args: args{
+				v: struct{}{},
+			},
+			want: want{
+				err: ErrWrongType,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := AsFloat64(tc.args.v)
+			assert.True(t, errors.Is(err, tc.want.err), "got error %v", err)
+			assert.Equal(t, tc.want.output, output)
+		})
+	}
+}
+
+func TestFloatAsInt(t *testing.T) {
+	i, err := FloatAsInt(-1540.9)
+	assert.NoError(t, err)
+	assert.Equal(t, -1540, i)
+
+	_, err = FloatAsInt(1e300)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	i, err = FloatAsInt("1.5")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	var cerr *ConvError
+	_, err = FloatAsInt(struct{}{})
+	assert.True(t, errors.As(err, &cerr))
+	assert.Equal(t, "FloatAsInt", cerr.Func)
+	assert.Equal(t, ErrWrongType, cerr.Err)
+}
+
+func TestAsInt(t *testing.T) {
+	seven := 7
+	var nilInt *int
+	type args struct {
+		v interface{}
+	}
+	type want struct {
+		output int
+		err    error
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Int",
+			args: args{
+				v: 7,
+			},
+			want: want{
+				output: 7,
+			},
+		},
+		{
+			name: "Int8",
+			args: args{
+				v: int8(-7),
+			},
+			want: want{
+				output: -7,
+			},
+		},
+		{
+			name: "Uint16",
+			args: args{
+				v: uint16(7),
+			},
+			want: want{
+				output: 7,
+			},
+		},
+		{
+			name: "Int64",
+			args: args{
+				v: int64(7),
+			},
+			want: want{
+				output: 7,
+			},
+		},
+		{
+			name: "Whole Float64",
+			args: args{
+				v: 7.0,
+			},
+			want: want{
+				output: 7,
+			},
+		},
+		{
+			name: "Float32",
+			args: args{
+				v: float32(7),
+			},
+			want: want{
+				output: 7,
+			},
+		},
+		{
+			name: "JSON Number",
+			args: args{
+				v: json.Number("7"),
+			},
+			want: want{
+				output: 7,
+			},
+		},
+		{
+			name: "Float JSON Number",
+			args: args{
+				v: json.Number("7e0"),
+			},
+			want: want{
+				output: 7,
+			},
+		},
+		{
+			name: "String",
+			args: args{
+				v: " 7 ",
+			},
+			want: want{
+				output: 7,
+			},
+		},
+		{
+			name: "Bool",
+			args: args{
+				v: true,
+			},
+			want: want{
+				output: 1,
+			},
+		},
+		{
+			name: "Pointer",
+			args: args{
+				v: &seven,
+			},
+			want: want{
+				output: 7,
+			},
+		},
+		{
+			name: "Nil Pointer",
+			args: args{
+				v: nilInt,
+			},
+			want: want{
+				err: ErrBlank,
+			},
+		},
+		{
+			name: "Blank String",
+			args: args{
+				v: "",
+			},
+			want: want{
+				err: ErrBlank,
+			},
+		},
+		{
+			name: "Fraction",
+			args: args{
+				v: 7.5,
+			},
+			want: want{
+				err: ErrTruncated,
+			},
+		},
+		{
+			name: "Fraction String",
+			args: args{
+				v: "7.5",
+			},
+			want: want{
+				err: ErrTruncated,
+			},
+		},
+		{
+			name: "Huge Uint64",
+			args: args{
+				v: uint64(1 << 63),
+			},
+			want: want{
+				err: ErrOverflow,
+			},
+		},
+		{
+			name: "Huge Float",
+			args: args{
+				v: 1e19,
+			},
+			want: want{
+				err: ErrOverflow,
+			},
+		},
+		{
+			name: "Not A Number",
+			args: args{
+				v: "seven",
+			},
+			want: want{
+				err: ErrSyntax,
+			},
+		},
+		{
+			name: "Slice",
+			args: args{
+				v: []int{7},
+			},
+			want: want{
+				err: ErrWrongType,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := AsInt(tc.args.v)
+			assert.True(t, errors.Is(err, tc.want.err), "got error %v", err)
+			assert.Equal(t, tc.want.output, output)
+		})
+	}
+}
+
+func TestAsMap(t *testing.T) {
+	m, err := AsMap(Prop{"a": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": 1}, m)
+
+	_, err = AsMap([]interface{}{})
+	assert.True(t, errors.Is(err, ErrWrongType))
+
+	n, err := AsInt(7)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, n)
+}
+
+func TestParseJSONMap(t *testing.T) {
+	m, err := ParseJSONMap(json.RawMessage(`{"Name":"Deepak","KD":12}`))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"Name": "Deepak", "KD": 12.0}, m)
+
+	_, err = ParseJSONMap(json.RawMessage(`{"Name":`))
+	var serr *json.SyntaxError
+	assert.True(t, errors.As(err, &serr))
+
+	_, err = ParseJSONMap(json.RawMessage(``))
+	assert.True(t, errors.Is(err, ErrBlank))
+
+	want := map[string]interface{}{"id": 1.0}
+	raw := json.RawMessage(`{"id":1}`)
+	for _, v := range []interface{}{&raw, []byte(`{"id":1}`), ` {"id":1} `, strings.NewReader(`{"id":1}`)} {
+		m, err = ParseJSONMap(v)
+		assert.NoError(t, err, "%T", v)
+		assert.Equal(t, want, m, "%T", v)
+	}
+
+	m, err = ParseJSONMap(`{"id":12345678901234567890}`, UseNumber())
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("12345678901234567890"), m["id"])
+
+	_, err = ParseJSONMap(`[1,2]`)
+	var terr *json.UnmarshalTypeError
+	assert.True(t, errors.As(err, &terr))
+
+	_, err = ParseJSONMap(`{"id":1} {"id":2}`)
+	assert.True(t, errors.Is(err, ErrTrailingData))
+
+	_, err = ParseJSONMap((*json.RawMessage)(nil))
+	assert.True(t, errors.Is(err, ErrBlank))
+
+	_, err = ParseJSONMap("   ")
+	assert.True(t, errors.Is(err, ErrBlank))
+
+	_, err = ParseJSONMap(42)
+	assert.True(t, errors.Is(err, ErrWrongType))
+}
+
+func TestParseJSONSlice(t *testing.T) {
+	s, err := ParseJSONSlice([]byte(`[{"id":1},"two",3]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": 1.0}, "two", 3.0}, s)
+
+	s, err = ParseJSONSlice(strings.NewReader(`[9007199254740993]`), UseNumber())
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{json.Number("9007199254740993")}, s)
+
+	_, err = ParseJSONSlice(`{"id":1}`)
+	var terr *json.UnmarshalTypeError
+	assert.True(t, errors.As(err, &terr))
+
+	_, err = ParseJSONSlice(``)
+	assert.True(t, errors.Is(err, ErrBlank))
+}
@@ -0,0 +1,147 @@
+package utility
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// maxStackDepth is the number of frames kept by a StackError.
+const maxStackDepth = 32
+
+// StackError is an error annotated with the stack trace of where it was
+// created. Format it with %+v to print the message followed by the stack.
+type StackError struct {
+	Msg   string // context added by Wrap, may be empty
+	Err   error
+	stack []uintptr
+
+	// logged marks the errors Panic logged before panicking, so Recover
+	// doesn't log them twice.
+	logged bool
+}
+
+func (e *StackError) Error() string {
+	switch {
+	case e.Msg == "":
+		return e.Err.Error()
+	case e.Err == nil:
+		return e.Msg
+	}
+	return e.Msg + ": " + e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *StackError) Unwrap() error {
+	return e.Err
+}
+
+// Format prints the message for %v and %s, the quoted message for %q, and the
+// message followed by the stack trace for %+v.
+func (e *StackError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, e.Error())
+			io.WriteString(s, "\n")
+			io.WriteString(s, Stack(e))
+			return
+		}
+		io.WriteString(s, e.Error())
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	}
+}
+
+// Frames returns the stack trace of where e was created, innermost call first.
+func (e *StackError) Frames() []runtime.Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+	var out []runtime.Frame
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		f, more := frames.Next()
+		out = append(out, f)
+		if !more {
+			return out
+		}
+	}
+}
+
+// callers returns the program counters of the calling stack, skipping the
+// caller of callers and skip more frames.
+func callers(skip int) []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+2, pcs)
+	return pcs[:n]
+}
+
+// WithStack returns err annotated with the current stack trace. It returns
+// err unchanged if it is nil or already carries a stack trace.
+func WithStack(err error) error {
+	return withStack(err, 1)
+}
+
+func withStack(err error, skip int) error {
+	if err == nil {
+		return nil
+	}
+	var se *StackError
+	if errors.As(err, &se) {
+		return err
+	}
+	return &StackError{Err: err, stack: callers(skip + 1)}
+}
+
+// Wrap returns err with msg prepended, annotated with the current stack trace
+// unless err already carries one. It returns nil if err is nil.
+func Wrap(err error, msg string) error {
+	return wrap(err, msg)
+}
+
+// Wrapf is Wrap with a formatted message.
+func Wrapf(err error, format string, args ...interface{}) error {
+	return wrap(err, fmt.Sprintf(format, args...))
+}
+
+func wrap(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	e := &StackError{Msg: msg, Err: err}
+	var se *StackError
+	if !errors.As(err, &se) {
+		e.stack = callers(2)
+	}
+	return e
+}
+
+// Stack returns the stack trace carried by err, formatted one
+// "function\n\tfile:line" pair per frame, or "" if it has none. The trace is
+// the one captured deepest in the chain, where the error originated.
+func Stack(err error) string {
+	var frames []runtime.Frame
+	for err != nil {
+		if se, ok := err.(*StackError); ok && len(se.stack) > 0 {
+			frames = se.Frames()
+		}
+		err = errors.Unwrap(err)
+	}
+
+	var b strings.Builder
+	for _, f := range frames {
+		b.WriteString(f.Function)
+		b.WriteString("\n\t")
+		b.WriteString(f.File)
+		b.WriteString(":")
+		b.WriteString(strconv.Itoa(f.Line))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
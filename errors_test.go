@@ -0,0 +1,60 @@
+package utility
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithStack(t *testing.T) {
+	assert.Nil(t, WithStack(nil))
+	assert.Nil(t, Wrap(nil, "ignored"))
+
+	err := WithStack(ErrBlank)
+	assert.True(t, errors.Is(err, ErrBlank))
+	assert.Equal(t, ErrBlank.Error(), err.Error())
+	assert.Equal(t, err, WithStack(err))
+
+	var se *StackError
+	assert.True(t, errors.As(err, &se))
+	assert.Equal(t, "utility.TestWithStack", se.Frames()[0].Function)
+
+	stack := Stack(err)
+	assert.True(t, strings.HasPrefix(stack, "utility.TestWithStack\n\t"), stack)
+	assert.Contains(t, stack, "errors_test.go:")
+	assert.Equal(t, "", Stack(ErrBlank))
+}
+
+func TestWrap(t *testing.T) {
+	inner := Wrap(&PathError{Path: "a.b", Err: ErrPathNotFound}, "loading contact")
+	outer := Wrapf(inner, "webhook %d", 7)
+
+	assert.Equal(t, `webhook 7: loading contact: utility: path "a.b": path not found`, outer.Error())
+	assert.True(t, errors.Is(outer, ErrPathNotFound))
+	var pe *PathError
+	assert.True(t, errors.As(outer, &pe))
+
+	var se *StackError
+	assert.True(t, errors.As(outer, &se))
+	assert.Nil(t, se.Frames(), "the stack is only captured once")
+	assert.Contains(t, Stack(outer), "utility.TestWrap")
+
+	assert.Equal(t, outer.Error(), fmt.Sprintf("%v", outer))
+	assert.Equal(t, outer.Error(), fmt.Sprintf("%s", outer))
+	assert.Equal(t, fmt.Sprintf("%q", outer.Error()), fmt.Sprintf("%q", outer))
+	assert.Equal(t, outer.Error()+"\n"+Stack(outer), fmt.Sprintf("%+v", outer))
+}
+
+func TestPanicWithStack(t *testing.T) {
+	s := &Service{Logger: NewRecordLogger()}
+	defer func() {
+		err, ok := recover().(error)
+		assert.True(t, ok)
+		assert.True(t, errors.Is(err, ErrSyntax))
+		assert.Contains(t, Stack(err), "utility.TestPanicWithStack")
+	}()
+	s.Panic(&ConvError{Func: "ParseInt", Value: "x", Err: ErrSyntax})
+}
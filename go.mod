@@ -1,6 +1,6 @@
 module utility
 
-go 1.17
+go 1.21
 
 require (
 	github.com/satori/go.uuid v1.2.0
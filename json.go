@@ -0,0 +1,222 @@
+package utility
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	// ErrTooLarge is returned when JSON input is larger than the MaxSize option allows.
+	ErrTooLarge = errors.New("JSON input too large")
+	// ErrTooDeep is returned when JSON input nests deeper than the MaxDepth option allows.
+	ErrTooDeep = errors.New("JSON input nested too deep")
+	// ErrTrailingData is returned when JSON input has more data after the first value.
+	ErrTrailingData = errors.New("unexpected data after JSON value")
+)
+
+// JSONError reports a failed JSON encoding or decoding. Err is one of the Err*
+// values or an encoding/json error such as *json.SyntaxError.
+type JSONError struct {
+	Op  string // "marshal" or "unmarshal"
+	Err error
+
+	// body marks errors of DecodeBody, which AsAppError reports to the client.
+	body bool
+}
+
+func (e *JSONError) Error() string {
+	return fmt.Sprintf("utility: JSON %s: %v", e.Op, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *JSONError) Unwrap() error {
+	return e.Err
+}
+
+// JSONOption configures MarshalE and UnmarshalE.
+type JSONOption func(*jsonOptions)
+
+type jsonOptions struct {
+	disallowUnknownFields bool
+	useNumber             bool
+	maxDepth              int
+	maxSize               int64
+	escapeHTML            bool
+}
+
+func newJSONOptions(opts []JSONOption) jsonOptions {
+	o := jsonOptions{escapeHTML: true}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// DisallowUnknownFields makes decoding into a struct fail on keys that match no field.
+func DisallowUnknownFields() JSONOption {
+	return func(o *jsonOptions) { o.disallowUnknownFields = true }
+}
+
+// UseNumber decodes numbers into interface{} values as json.Number instead of float64.
+func UseNumber() JSONOption {
+	return func(o *jsonOptions) { o.useNumber = true }
+}
+
+// MaxDepth limits how deep objects and arrays can nest, 0 means no limit.
+func MaxDepth(n int) JSONOption {
+	return func(o *jsonOptions) { o.maxDepth = n }
+}
+
+// MaxSize limits the input size in bytes, 0 means no limit.
+func MaxSize(n int64) JSONOption {
+	return func(o *jsonOptions) { o.maxSize = n }
+}
+
+// EscapeHTML controls whether <, > and & are escaped in strings, which
+// encoding/json does by default.
+func EscapeHTML(on bool) JSONOption {
+	return func(o *jsonOptions) { o.escapeHTML = on }
+}
+
+// MarshalE returns the JSON encoding of v.
+func MarshalE(v interface{}, opts ...JSONOption) ([]byte, error) {
+	o := newJSONOptions(opts)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(o.escapeHTML)
+	if err := enc.Encode(v); err != nil {
+		return nil, &JSONError{Op: "marshal", Err: err}
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// UnmarshalE parses body into v. Unlike json.Unmarshal, anything after the
+// first JSON value other than white space is reported as ErrTrailingData. As
+// with json.Unmarshal, v is left untouched when body is not valid JSON.
+func UnmarshalE(body []byte, v interface{}, opts ...JSONOption) error {
+	o := newJSONOptions(opts)
+	if o.maxSize > 0 && int64(len(body)) > o.maxSize {
+		return &JSONError{Op: "unmarshal", Err: ErrTooLarge}
+	}
+	// The value is checked on its own first, so trailing data and the depth
+	// limit are reported before anything is stored in v.
+	var raw json.RawMessage
+	err := decodeJSON(o.decoder(bytes.NewReader(body)), &raw)
+	if err == nil {
+		err = decodeJSON(o.decoder(bytes.NewReader(raw)), v)
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		// Report a truncated document as a *json.SyntaxError, like json.Unmarshal.
+		if serr := json.Unmarshal(body, new(json.RawMessage)); serr != nil {
+			err = &JSONError{Op: "unmarshal", Err: serr}
+		}
+	}
+	return err
+}
+
+// decodeJSON decodes the only value read by dec into v.
+func decodeJSON(dec *json.Decoder, v interface{}) error {
+	if err := dec.Decode(v); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return &JSONError{Op: "unmarshal", Err: err}
+	}
+	if err := expectEOF(dec); err != nil {
+		return &JSONError{Op: "unmarshal", Err: err}
+	}
+	return nil
+}
+
+// expectEOF returns ErrTrailingData if dec has anything left but white space.
+func expectEOF(dec *json.Decoder) error {
+	_, err := dec.Token()
+	switch {
+	case err == io.EOF:
+		return nil
+	case err == nil || errors.As(err, new(*json.SyntaxError)):
+		return ErrTrailingData
+	}
+	return err
+}
+
+// decoder returns a json.Decoder reading r with the decoding options set.
+func (o jsonOptions) decoder(r io.Reader) *json.Decoder {
+	if o.maxSize > 0 {
+		r = &sizeLimitReader{r: r, n: o.maxSize}
+	}
+	if o.maxDepth > 0 {
+		r = &depthLimitReader{r: r, max: o.maxDepth}
+	}
+	dec := json.NewDecoder(r)
+	if o.disallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if o.useNumber {
+		dec.UseNumber()
+	}
+	return dec
+}
+
+// sizeLimitReader reads at most n bytes from r and fails with ErrTooLarge
+// if r has more.
+type sizeLimitReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *sizeLimitReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, ErrTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
+
+// depthLimitReader fails with ErrTooDeep once the objects and arrays read
+// from r nest deeper than max. Brackets inside strings are skipped; the data
+// is not otherwise validated.
+type depthLimitReader struct {
+	r                 io.Reader
+	max, depth        int
+	inString, escaped bool
+}
+
+func (l *depthLimitReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	for _, c := range p[:n] {
+		switch {
+		case l.escaped:
+			l.escaped = false
+		case l.inString:
+			switch c {
+			case '\\':
+				l.escaped = true
+			case '"':
+				l.inString = false
+			}
+		case c == '"':
+			l.inString = true
+		case c == '{' || c == '[':
+			l.depth++
+			if l.depth > l.max {
+				return 0, ErrTooDeep
+			}
+		case c == '}' || c == ']':
+			l.depth--
+		}
+	}
+	return n, err
+}
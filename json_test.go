@@ -0,0 +1,160 @@
+package utility
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalE(t *testing.T) {
+	type message struct {
+		Target string `json:"target"`
+		Body   string `json:"body"`
+	}
+
+	type args struct {
+		body []byte
+		opts []JSONOption
+	}
+	type want struct {
+		output message
+		err    error
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Valid",
+			args: args{
+				body: []byte(`{"target":"fb:1:2","body":"hi"}`),
+			},
+			want: want{
+				output: message{Target: "fb:1:2", Body: "hi"},
+			},
+		},
+		{
+			name: "Unknown Field Allowed",
+			args: args{
+				body: []byte(`{"target":"fb:1:2","extra":1}`),
+			},
+			want: want{
+				output: message{Target: "fb:1:2"},
+			},
+		},
+		{
+			name: "Unknown Field Disallowed",
+			args: args{
+				body: []byte(`{"target":"fb:1:2","extra":1}`),
+				opts: []JSONOption{DisallowUnknownFields()},
+			},
+			want: want{
+				output: message{Target: "fb:1:2"},
+				err:    &JSONError{},
+			},
+		},
+		{
+			name: "Too Large",
+			args: args{
+				body: []byte(`{"target":"fb:1:2"}`),
+				opts: []JSONOption{MaxSize(10)},
+			},
+			want: want{
+				err: ErrTooLarge,
+			},
+		},
+		{
+			name: "Too Deep",
+			args: args{
+				body: []byte(`{"target":"[[[","body":{"a":[{"b":1}]}}`),
+				opts: []JSONOption{MaxDepth(3)},
+			},
+			want: want{
+				err: ErrTooDeep,
+			},
+		},
+		{
+			name: "Deep Enough",
+			args: args{
+				body: []byte(`{"target":"[[[{{{"}`),
+				opts: []JSONOption{MaxDepth(1)},
+			},
+			want: want{
+				output: message{Target: "[[[{{{"},
+			},
+		},
+		{
+			name: "Trailing Data",
+			args: args{
+				body: []byte(`{"target":"fb:1:2"} {}`),
+			},
+			want: want{
+				err: ErrTrailingData,
+			},
+		},
+		{
+			name: "Empty",
+			args: args{
+				body: []byte(` `),
+			},
+			want: want{
+				err: &JSONError{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var output message
+			err := UnmarshalE(tc.args.body, &output, tc.args.opts...)
+			switch want := tc.want.err.(type) {
+			case nil:
+				assert.NoError(t, err)
+			case *JSONError:
+				assert.True(t, errors.As(err, &want), "got error %v", err)
+			default:
+				assert.True(t, errors.Is(err, want), "got error %v", err)
+			}
+			assert.Equal(t, tc.want.output, output)
+		})
+	}
+}
+
+func TestUnmarshalEUseNumber(t *testing.T) {
+	var v map[string]interface{}
+	assert.NoError(t, UnmarshalE([]byte(`{"id":12345678901234567890}`), &v, UseNumber()))
+	assert.Equal(t, json.Number("12345678901234567890"), v["id"])
+}
+
+func TestUnmarshalELeavesValueOnError(t *testing.T) {
+	m := map[string]interface{}{}
+	assert.True(t, errors.Is(UnmarshalE([]byte(`{"a":1} x`), &m), ErrTrailingData))
+	assert.Empty(t, m)
+
+	assert.True(t, errors.Is(UnmarshalE([]byte(`{"a":{"b":[1]}}`), &m, MaxDepth(2)), ErrTooDeep))
+	assert.Empty(t, m)
+
+	(&Service{Logger: NewRecordLogger()}).Unmarshal([]byte(`{"a":1}{"b":2}`), &m)
+	assert.Empty(t, m)
+}
+
+func TestMarshalE(t *testing.T) {
+	v := map[string]string{"body": "<b>hi</b> & bye"}
+
+	data, err := MarshalE(v)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"body":"\u003cb\u003ehi\u003c/b\u003e \u0026 bye"}`, string(data))
+
+	data, err = MarshalE(v, EscapeHTML(false))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"body":"<b>hi</b> & bye"}`, string(data))
+
+	_, err = MarshalE(map[string]interface{}{"f": func() {}})
+	var je *JSONError
+	assert.True(t, errors.As(err, &je))
+	assert.Equal(t, "marshal", je.Op)
+}
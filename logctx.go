@@ -0,0 +1,180 @@
+package utility
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Names of the log fields set by the context helpers.
+const (
+	FieldRequestID = "request_id"
+	FieldTarget    = "target"
+	FieldChannel   = "channel"
+	FieldAccountID = "account_id"
+)
+
+type ctxKey int
+
+const (
+	loggerKey ctxKey = iota
+	fieldsKey
+	requestIDKey
+	targetKey
+)
+
+// WithLogger returns a copy of ctx carrying log. LoggerFrom returns it with
+// the fields added to ctx. Use WithLog for a Logger of another kind.
+func WithLogger(ctx context.Context, log *logrus.Entry) context.Context {
+	return WithLog(ctx, LogrusLogger(log))
+}
+
+// LoggerFrom returns the logger of ctx with the fields added to ctx, falling
+// back to the logger of the default Service.
+func LoggerFrom(ctx context.Context) *logrus.Entry {
+	return Default().LoggerFrom(ctx)
+}
+
+// LoggerFrom returns the logger of ctx with the fields added to ctx, falling
+// back to the Service's Log. A Logger set with WithLog that does not write to
+// logrus is ignored, use LogFrom for it.
+func (s *Service) LoggerFrom(ctx context.Context) *logrus.Entry {
+	log, ok := ctx.Value(loggerKey).(logrusLogger)
+	if !ok {
+		log = logrusLogger{entry: s.logrusEntry()}
+	}
+	fields, _ := ctx.Value(fieldsKey).(map[string]interface{})
+	if len(fields) == 0 {
+		return log.entry
+	}
+	return log.entry.WithFields(fields)
+}
+
+// WithFields returns a copy of ctx with fields added to the ones logged by
+// LoggerFrom and LogFrom.
+func WithFields(ctx context.Context, fields logrus.Fields) context.Context {
+	return withFields(ctx, fields)
+}
+
+// WithLog is WithLogger for any Logger, e.g. a *slog.Logger wrapped with
+// SlogLogger or a RecordLogger. LogFrom returns it with the fields added to ctx.
+func WithLog(ctx context.Context, log Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, log)
+}
+
+// LogFrom is LoggerFrom returning a Logger, falling back to the logger of the
+// default Service.
+func LogFrom(ctx context.Context) Logger {
+	return Default().LogFrom(ctx)
+}
+
+// LogFrom is LoggerFrom returning a Logger, falling back to the Service's
+// Logger.
+func (s *Service) LogFrom(ctx context.Context) Logger {
+	log, _ := ctx.Value(loggerKey).(Logger)
+	if log == nil {
+		log = s.logger()
+	}
+	fields, _ := ctx.Value(fieldsKey).(map[string]interface{})
+	if len(fields) == 0 {
+		return log
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kv := make([]interface{}, 0, 2*len(keys))
+	for _, k := range keys {
+		kv = append(kv, k, fields[k])
+	}
+	return log.With(kv...)
+}
+
+// WithLogFields is WithFields taking alternating keys and values, as Logger
+// does. A key set again replaces the earlier value.
+func WithLogFields(ctx context.Context, kv ...interface{}) context.Context {
+	return withFields(ctx, kvFields(kv))
+}
+
+func withFields(ctx context.Context, fields map[string]interface{}) context.Context {
+	prev, _ := ctx.Value(fieldsKey).(map[string]interface{})
+	merged := make(map[string]interface{}, len(prev)+len(fields))
+	for k, v := range prev {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, fieldsKey, merged)
+}
+
+// WithRequestID returns a copy of ctx carrying the ID of the request or
+// webhook delivery being handled, logged as request_id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if scope := scopeFrom(ctx); scope != nil {
+		scope.mu.Lock()
+		scope.requestID = id
+		scope.mu.Unlock()
+	}
+	ctx = context.WithValue(ctx, requestIDKey, id)
+	return WithLogFields(ctx, FieldRequestID, id)
+}
+
+// RequestIDFrom returns the request ID set by WithRequestID, or "".
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithTarget returns a copy of ctx carrying target, logged as target along
+// with its channel and, for channel targets, its account ID.
+func WithTarget(ctx context.Context, target string) context.Context {
+	if scope := scopeFrom(ctx); scope != nil {
+		scope.mu.Lock()
+		scope.target = target
+		scope.mu.Unlock()
+	}
+	ctx = context.WithValue(ctx, targetKey, target)
+	kv := []interface{}{FieldTarget, target, FieldChannel, Origin(target)}
+	if parts, err := ParseTarget(target); err == nil && parts.Account != "" {
+		kv = append(kv, FieldAccountID, parts.Account)
+	}
+	return WithLogFields(ctx, kv...)
+}
+
+// TargetFrom returns the target set by WithTarget, or "".
+func TargetFrom(ctx context.Context) string {
+	target, _ := ctx.Value(targetKey).(string)
+	return target
+}
+
+// WithAccountID returns a copy of ctx with the account ID logged as account_id.
+func WithAccountID(ctx context.Context, id string) context.Context {
+	return WithLogFields(ctx, FieldAccountID, id)
+}
+
+// PrintErrorCtx logs e as an error through the logger of ctx.
+func PrintErrorCtx(ctx context.Context, e error) {
+	Default().PrintErrorCtx(ctx, e)
+}
+
+// PrintErrorCtx logs e as an error through the logger of ctx. Unlike
+// PrintError, which logs as info, it uses the error level.
+func (s *Service) PrintErrorCtx(ctx context.Context, e error) {
+	if e != nil {
+		s.LogFrom(ctx).Error(e.Error())
+	}
+}
+
+// PrintCtx is Print through the logger of ctx.
+func PrintCtx(ctx context.Context, str interface{}) {
+	Default().PrintCtx(ctx, str)
+}
+
+// PrintCtx is Print through the logger of ctx.
+func (s *Service) PrintCtx(ctx context.Context, str interface{}) {
+	s.LogFrom(ctx).Info(fmt.Sprint(str))
+}
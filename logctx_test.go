@@ -0,0 +1,97 @@
+package utility
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerFromContext(t *testing.T) {
+	rec := NewRecordLogger()
+	ctx := WithLog(context.Background(), rec)
+	ctx = WithRequestID(ctx, "req-1")
+	ctx = WithTarget(ctx, "fb:page1:user1")
+
+	assert.Equal(t, "req-1", RequestIDFrom(ctx))
+	assert.Equal(t, "fb:page1:user1", TargetFrom(ctx))
+
+	PrintErrorCtx(ctx, errors.New("send failed"))
+	PrintErrorCtx(ctx, nil)
+	PrintCtx(WithAccountID(ctx, "acct-9"), "delivered")
+
+	assert.Equal(t, []LogRecord{
+		{
+			Level: "error",
+			Msg:   "send failed",
+			Fields: map[string]interface{}{
+				FieldRequestID: "req-1",
+				FieldTarget:    "fb:page1:user1",
+				FieldChannel:   "Facebook",
+				FieldAccountID: "page1",
+			},
+		},
+		{
+			Level: "info",
+			Msg:   "delivered",
+			Fields: map[string]interface{}{
+				FieldRequestID: "req-1",
+				FieldTarget:    "fb:page1:user1",
+				FieldChannel:   "Facebook",
+				FieldAccountID: "acct-9",
+			},
+		},
+	}, rec.Records())
+}
+
+func TestLoggerFromContextFallback(t *testing.T) {
+	rec := NewRecordLogger()
+	s := &Service{Logger: rec}
+
+	s.PrintErrorCtx(WithTarget(context.Background(), "19700000987"), errors.New("no logger in context"))
+	assert.Equal(t, []LogRecord{
+		{
+			Level: "error",
+			Msg:   "no logger in context",
+			Fields: map[string]interface{}{
+				FieldTarget:  "19700000987",
+				FieldChannel: "SMS",
+			},
+		},
+	}, rec.Records())
+	assert.Equal(t, "", RequestIDFrom(context.Background()))
+
+	log, buf := testLogger()
+	s = NewService(log, nil, Options{})
+	s.PrintErrorCtx(WithRequestID(context.Background(), "req-2"), errors.New("through logrus"))
+	assert.Contains(t, buf.String(), "level=error")
+	assert.Contains(t, buf.String(), "request_id=req-2")
+}
+
+func TestLogrusFromContext(t *testing.T) {
+	log, buf := testLogger()
+	ctx := WithLogger(context.Background(), log)
+	ctx = WithFields(ctx, logrus.Fields{"job": "sync"})
+	ctx = WithRequestID(ctx, "req-3")
+
+	LoggerFrom(ctx).Println("through the context")
+	assert.Contains(t, buf.String(), "level=info")
+	assert.Contains(t, buf.String(), "job=sync")
+	assert.Contains(t, buf.String(), "request_id=req-3")
+
+	buf.Reset()
+	PrintCtx(ctx, "through LogFrom")
+	assert.Contains(t, buf.String(), "job=sync")
+
+	rec := NewRecordLogger()
+	LogFrom(WithLog(ctx, rec)).Info("both")
+	assert.Equal(t, map[string]interface{}{"job": "sync", FieldRequestID: "req-3"}, rec.Records()[0].Fields)
+
+	fallback, fallbackBuf := testLogger()
+	s := NewService(fallback, nil, Options{})
+	assert.Equal(t, fallback, s.LoggerFrom(context.Background()))
+	s.LoggerFrom(WithLog(ctx, rec)).Warn("not logrus")
+	assert.Contains(t, fallbackBuf.String(), "request_id=req-3")
+}
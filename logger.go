@@ -0,0 +1,195 @@
+package utility
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Logger is what the package logs through. kv are alternating keys and
+// values, e.g. Error("send failed", "target", target).
+type Logger interface {
+	Debug(msg string, kv ...interface{})
+	Info(msg string, kv ...interface{})
+	Warn(msg string, kv ...interface{})
+	Error(msg string, kv ...interface{})
+	// With returns a Logger that adds kv to every message.
+	With(kv ...interface{}) Logger
+}
+
+// kvFields turns alternating keys and values into a map. A key that is not a
+// string is formatted with fmt.Sprint, a value missing its key is logged
+// under "!BADKEY" as log/slog does.
+func kvFields(kv []interface{}) map[string]interface{} {
+	fields := make(map[string]interface{}, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		if i+1 == len(kv) {
+			fields["!BADKEY"] = kv[i]
+			break
+		}
+		key, ok := kv[i].(string)
+		if !ok {
+			key = fmt.Sprint(kv[i])
+		}
+		fields[key] = kv[i+1]
+	}
+	return fields
+}
+
+type logrusLogger struct {
+	entry *logrus.Entry
+}
+
+// LogrusLogger returns a Logger writing to entry.
+func LogrusLogger(entry *logrus.Entry) Logger {
+	return logrusLogger{entry: entry}
+}
+
+func (l logrusLogger) Debug(msg string, kv ...interface{}) {
+	l.entry.WithFields(kvFields(kv)).Debug(msg)
+}
+
+func (l logrusLogger) Info(msg string, kv ...interface{}) {
+	l.entry.WithFields(kvFields(kv)).Info(msg)
+}
+
+func (l logrusLogger) Warn(msg string, kv ...interface{}) {
+	l.entry.WithFields(kvFields(kv)).Warn(msg)
+}
+
+func (l logrusLogger) Error(msg string, kv ...interface{}) {
+	l.entry.WithFields(kvFields(kv)).Error(msg)
+}
+
+func (l logrusLogger) With(kv ...interface{}) Logger {
+	return logrusLogger{entry: l.entry.WithFields(kvFields(kv))}
+}
+
+// logPanic logs msg at logrus' panic level when log writes to logrus, as
+// logrus.Entry.Panic does but without panicking, and at error level otherwise.
+func logPanic(log Logger, msg string, kv ...interface{}) {
+	l, ok := log.(logrusLogger)
+	if !ok {
+		log.Error(msg, kv...)
+		return
+	}
+	defer func() {
+		if rec := recover(); rec != nil {
+			if _, ok := rec.(*logrus.Entry); !ok {
+				panic(rec)
+			}
+		}
+	}()
+	l.entry.WithFields(kvFields(kv)).Log(logrus.PanicLevel, msg)
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// SlogLogger returns a Logger writing to l.
+func SlogLogger(l *slog.Logger) Logger {
+	return slogLogger{l: l}
+}
+
+func (l slogLogger) Debug(msg string, kv ...interface{}) {
+	l.l.Log(context.Background(), slog.LevelDebug, msg, kv...)
+}
+
+func (l slogLogger) Info(msg string, kv ...interface{}) {
+	l.l.Log(context.Background(), slog.LevelInfo, msg, kv...)
+}
+
+func (l slogLogger) Warn(msg string, kv ...interface{}) {
+	l.l.Log(context.Background(), slog.LevelWarn, msg, kv...)
+}
+
+func (l slogLogger) Error(msg string, kv ...interface{}) {
+	l.l.Log(context.Background(), slog.LevelError, msg, kv...)
+}
+
+func (l slogLogger) With(kv ...interface{}) Logger {
+	return slogLogger{l: l.l.With(kv...)}
+}
+
+// LogRecord is a message kept by a RecordLogger.
+type LogRecord struct {
+	Level  string // "debug", "info", "warn" or "error"
+	Msg    string
+	Fields map[string]interface{}
+}
+
+// RecordLogger is a Logger that keeps every message in memory, for tests.
+// Loggers returned by With share the records of their parent.
+type RecordLogger struct {
+	mu      *sync.Mutex
+	records *[]LogRecord
+	fields  map[string]interface{}
+}
+
+// NewRecordLogger returns an empty RecordLogger.
+func NewRecordLogger() *RecordLogger {
+	return &RecordLogger{mu: &sync.Mutex{}, records: &[]LogRecord{}}
+}
+
+// Records returns a copy of the messages logged so far.
+func (r *RecordLogger) Records() []LogRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]LogRecord(nil), *r.records...)
+}
+
+// Reset drops the messages logged so far.
+func (r *RecordLogger) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*r.records = nil
+}
+
+func (r *RecordLogger) record(level, msg string, kv []interface{}) {
+	fields := make(map[string]interface{}, len(r.fields)+len(kv)/2)
+	for k, v := range r.fields {
+		fields[k] = v
+	}
+	for k, v := range kvFields(kv) {
+		fields[k] = v
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*r.records = append(*r.records, LogRecord{Level: level, Msg: msg, Fields: fields})
+}
+
+// Debug records a debug message.
+func (r *RecordLogger) Debug(msg string, kv ...interface{}) {
+	r.record("debug", msg, kv)
+}
+
+// Info records an info message.
+func (r *RecordLogger) Info(msg string, kv ...interface{}) {
+	r.record("info", msg, kv)
+}
+
+// Warn records a warning.
+func (r *RecordLogger) Warn(msg string, kv ...interface{}) {
+	r.record("warn", msg, kv)
+}
+
+// Error records an error message.
+func (r *RecordLogger) Error(msg string, kv ...interface{}) {
+	r.record("error", msg, kv)
+}
+
+// With returns a RecordLogger adding kv to every message.
+func (r *RecordLogger) With(kv ...interface{}) Logger {
+	fields := make(map[string]interface{}, len(r.fields)+len(kv)/2)
+	for k, v := range r.fields {
+		fields[k] = v
+	}
+	for k, v := range kvFields(kv) {
+		fields[k] = v
+	}
+	return &RecordLogger{mu: r.mu, records: r.records, fields: fields}
+}
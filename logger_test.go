@@ -0,0 +1,72 @@
+package utility
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogrusLogger(t *testing.T) {
+	entry, buf := testLogger()
+	log := LogrusLogger(entry).With("service", "sms")
+
+	log.Warn("slow upstream", "ms", 1200)
+	assert.Contains(t, buf.String(), "level=warning")
+	assert.Contains(t, buf.String(), `msg="slow upstream"`)
+	assert.Contains(t, buf.String(), "ms=1200")
+	assert.Contains(t, buf.String(), "service=sms")
+
+	buf.Reset()
+	log.Debug("hidden")
+	assert.Equal(t, "", buf.String())
+
+	log.Error("odd fields", "a", 1, "b")
+	assert.Contains(t, buf.String(), `!BADKEY=b`)
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	log := SlogLogger(l).With("service", "sms")
+
+	log.Debug("shown", "n", 1)
+	log.Error("failed", "target", "fb:1:2")
+	assert.Contains(t, buf.String(), "level=DEBUG msg=shown service=sms n=1")
+	assert.Contains(t, buf.String(), "level=ERROR msg=failed service=sms target=fb:1:2")
+}
+
+func TestRecordLogger(t *testing.T) {
+	rec := NewRecordLogger()
+	child := rec.With("request_id", "r1")
+	child.Info("child")
+	rec.Warn("parent", "k", "v")
+
+	assert.Equal(t, []LogRecord{
+		{Level: "info", Msg: "child", Fields: map[string]interface{}{"request_id": "r1"}},
+		{Level: "warn", Msg: "parent", Fields: map[string]interface{}{"k": "v"}},
+	}, rec.Records())
+
+	rec.Reset()
+	assert.Empty(t, rec.Records())
+}
+
+func TestServiceLogger(t *testing.T) {
+	rec := NewRecordLogger()
+	s := &Service{Logger: rec}
+
+	assert.Equal(t, 0, s.Int("xyz"))
+	s.Print("hello")
+	assert.Panics(t, func() {
+		s.Panic(errors.New("boom"))
+	})
+
+	records := rec.Records()
+	assert.Len(t, records, 3)
+	assert.Equal(t, "info", records[0].Level)
+	assert.Contains(t, records[0].Msg, "invalid syntax")
+	assert.Equal(t, LogRecord{Level: "info", Msg: "hello", Fields: map[string]interface{}{}}, records[1])
+	assert.Equal(t, LogRecord{Level: "error", Msg: "boom", Fields: map[string]interface{}{}}, records[2])
+}
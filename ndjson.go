@@ -0,0 +1,160 @@
+package utility
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// LineError reports a bad line of NDJSON input.
+type LineError struct {
+	Line int // 1-based
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("utility: NDJSON line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// NDJSONEncoder writes records of type T as newline-delimited JSON. It is safe
+// for concurrent use; each record is written as one whole line.
+type NDJSONEncoder[T any] struct {
+	mu   sync.Mutex
+	w    *bufio.Writer
+	opts []JSONOption
+}
+
+// NewNDJSONEncoder returns an encoder writing to w. Output is buffered, call
+// Flush when done.
+func NewNDJSONEncoder[T any](w io.Writer, opts ...JSONOption) *NDJSONEncoder[T] {
+	return &NDJSONEncoder[T]{w: bufio.NewWriter(w), opts: opts}
+}
+
+// Encode writes v as one line.
+func (e *NDJSONEncoder[T]) Encode(v T) error {
+	data, err := MarshalE(v, e.opts...)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, err := e.w.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Flush writes any buffered lines to the underlying writer.
+func (e *NDJSONEncoder[T]) Flush() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.w.Flush()
+}
+
+// NDJSONDecoder reads records of type T from newline-delimited JSON. Blank
+// lines are ignored. A MaxSize option limits the length of each line; longer
+// lines fail with ErrTooLarge without being read into memory.
+type NDJSONDecoder[T any] struct {
+	r       *bufio.Reader
+	opts    []JSONOption
+	maxSize int64
+	line    int
+	skip    bool
+	onSkip  func(*LineError)
+}
+
+// NewNDJSONDecoder returns a decoder reading from r.
+func NewNDJSONDecoder[T any](r io.Reader, opts ...JSONOption) *NDJSONDecoder[T] {
+	return &NDJSONDecoder[T]{
+		r:       bufio.NewReader(r),
+		opts:    opts,
+		maxSize: newJSONOptions(opts).maxSize,
+	}
+}
+
+// SkipBadLines makes Decode skip lines that can't be decoded instead of
+// returning their error. report, if not nil, is called with each skipped line.
+func (d *NDJSONDecoder[T]) SkipBadLines(report func(*LineError)) {
+	d.skip = true
+	d.onSkip = report
+}
+
+// Line returns the number of the last line read.
+func (d *NDJSONDecoder[T]) Line() int {
+	return d.line
+}
+
+// Decode returns the next record, or io.EOF at the end of the input. A line
+// that can't be decoded is reported as a *LineError; decoding can continue
+// with the next line.
+func (d *NDJSONDecoder[T]) Decode() (T, error) {
+	var zero T
+	for {
+		data, err := d.readLine()
+		if err == nil {
+			data = bytes.TrimSpace(data)
+			if len(data) == 0 {
+				continue
+			}
+			var v T
+			if err = UnmarshalE(data, &v, d.opts...); err == nil {
+				return v, nil
+			}
+		} else if !errors.Is(err, ErrTooLarge) {
+			return zero, err
+		}
+		lineErr := &LineError{Line: d.line, Err: err}
+		if !d.skip {
+			return zero, lineErr
+		}
+		if d.onSkip != nil {
+			d.onSkip(lineErr)
+		}
+	}
+}
+
+// readLine returns the next line, which may be the last one without a newline.
+// A line longer than MaxSize is read to its end but not kept, and fails with
+// ErrTooLarge like UnmarshalE does. A read error is reported on the line it
+// cut short.
+func (d *NDJSONDecoder[T]) readLine() ([]byte, error) {
+	var data []byte
+	read, tooLarge := 0, false
+	for {
+		chunk, err := d.r.ReadSlice('\n')
+		read += len(chunk)
+		if !tooLarge {
+			data = append(data, chunk...)
+			// One byte over MaxSize is left for the "\r" of "\r\n"; UnmarshalE
+			// checks the exact size once the line is trimmed.
+			if d.maxSize > 0 && int64(len(bytes.TrimRight(data, "\n"))) > d.maxSize+1 {
+				data, tooLarge = nil, true
+			}
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		line := d.line + 1
+		if read > 0 {
+			d.line = line
+		}
+		switch {
+		case err != nil && err != io.EOF:
+			// A line cut short by a read error is not decoded.
+			return nil, &LineError{Line: line, Err: err}
+		case tooLarge:
+			return nil, &JSONError{Op: "unmarshal", Err: ErrTooLarge}
+		case read > 0:
+			return data, nil
+		}
+		return nil, err
+	}
+}
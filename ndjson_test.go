@@ -0,0 +1,162 @@
+package utility
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRecord struct {
+	ID   int    `json:"id"`
+	Body string `json:"body"`
+}
+
+func TestNDJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewNDJSONEncoder[testRecord](&buf, EscapeHTML(false))
+	assert.NoError(t, enc.Encode(testRecord{ID: 1, Body: "a\nb"}))
+	assert.NoError(t, enc.Encode(testRecord{ID: 2, Body: "<c>"}))
+	assert.Equal(t, 0, buf.Len())
+	assert.NoError(t, enc.Flush())
+	assert.Equal(t, "{\"id\":1,\"body\":\"a\\nb\"}\n{\"id\":2,\"body\":\"<c>\"}\n", buf.String())
+}
+
+func TestNDJSONEncoderConcurrent(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewNDJSONEncoder[testRecord](&buf)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, enc.Encode(testRecord{ID: i, Body: strings.Repeat("x", 500)}))
+			assert.NoError(t, enc.Flush())
+		}(i)
+	}
+	wg.Wait()
+
+	dec := NewNDJSONDecoder[testRecord](&buf)
+	seen := map[int]bool{}
+	for {
+		r, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		seen[r.ID] = true
+	}
+	assert.Len(t, seen, 50)
+}
+
+func TestNDJSONDecoder(t *testing.T) {
+	input := "{\"id\":1,\"body\":\"a\"}\n\n  {\"id\":2}\r\n{\"id\":\"x\"}\n{\"id\":4}"
+
+	dec := NewNDJSONDecoder[testRecord](strings.NewReader(input))
+	r, err := dec.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, testRecord{ID: 1, Body: "a"}, r)
+
+	r, err = dec.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, testRecord{ID: 2}, r)
+	assert.Equal(t, 3, dec.Line())
+
+	_, err = dec.Decode()
+	var lineErr *LineError
+	assert.True(t, errors.As(err, &lineErr))
+	assert.Equal(t, 4, lineErr.Line)
+
+	r, err = dec.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, testRecord{ID: 4}, r)
+
+	_, err = dec.Decode()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestNDJSONDecoderSkipBadLines(t *testing.T) {
+	input := "{\"id\":1}\nnot json\n{\"id\":3,\"body\":\"too long\"}\n{\"id\":4}\n"
+
+	var skipped []*LineError
+	dec := NewNDJSONDecoder[testRecord](strings.NewReader(input), MaxSize(20))
+	dec.SkipBadLines(func(e *LineError) {
+		skipped = append(skipped, e)
+	})
+
+	var ids []int
+	for {
+		r, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		ids = append(ids, r.ID)
+	}
+	assert.Equal(t, []int{1, 4}, ids)
+	assert.Len(t, skipped, 2)
+	assert.Equal(t, 2, skipped[0].Line)
+	assert.Equal(t, 3, skipped[1].Line)
+	assert.True(t, errors.Is(skipped[1], ErrTooLarge))
+}
+
+func TestNDJSONDecoderLongLine(t *testing.T) {
+	huge := "{\"id\":2,\"body\":\"" + strings.Repeat("a", 1<<20)
+	input := "{\"id\":1}\n" + huge + "\"}\n{\"id\":3}\n" + huge
+
+	dec := NewNDJSONDecoder[testRecord](strings.NewReader(input), MaxSize(64))
+	r, err := dec.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, r.ID)
+
+	_, err = dec.Decode()
+	var lineErr *LineError
+	assert.True(t, errors.As(err, &lineErr))
+	assert.Equal(t, 2, lineErr.Line)
+	assert.True(t, errors.Is(err, ErrTooLarge))
+
+	r, err = dec.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, r.ID)
+
+	_, err = dec.Decode()
+	assert.True(t, errors.Is(err, ErrTooLarge))
+	assert.Equal(t, 4, dec.Line())
+
+	_, err = dec.Decode()
+	assert.Equal(t, io.EOF, err)
+}
+
+type errAfterReader struct {
+	data string
+	err  error
+}
+
+func (r *errAfterReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestNDJSONDecoderReadError(t *testing.T) {
+	broken := errors.New("connection reset")
+	dec := NewNDJSONDecoder[testRecord](&errAfterReader{data: "{\"id\":1}\n{\"id\":2,\"bo", err: broken})
+
+	r, err := dec.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, r.ID)
+
+	_, err = dec.Decode()
+	var lineErr *LineError
+	assert.True(t, errors.As(err, &lineErr))
+	assert.Equal(t, 2, lineErr.Line)
+	assert.True(t, errors.Is(err, broken))
+}
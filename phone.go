@@ -0,0 +1,206 @@
+package utility
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultPhoneRegion is the region assumed for national numbers when no region is given.
+var DefaultPhoneRegion = "US"
+
+var (
+	// ErrPhoneBlank is returned for a blank phone number.
+	ErrPhoneBlank = errors.New("phone number is blank")
+	// ErrPhoneInvalidChars is returned when a phone number contains characters other than digits and separators.
+	ErrPhoneInvalidChars = errors.New("phone number contains invalid characters")
+	// ErrPhoneUnknownRegion is returned for a national number whose region is not in the numbering plan table.
+	ErrPhoneUnknownRegion = errors.New("unknown phone region")
+	// ErrPhoneInvalidCountry is returned when an international number has no known country calling code.
+	ErrPhoneInvalidCountry = errors.New("invalid country calling code")
+	// ErrPhoneTooShort is returned when a phone number has too few digits.
+	ErrPhoneTooShort = errors.New("phone number is too short")
+	// ErrPhoneTooLong is returned when a phone number has too many digits.
+	ErrPhoneTooLong = errors.New("phone number is too long")
+)
+
+// PhoneError reports why a phone number could not be parsed.
+type PhoneError struct {
+	Input string
+	Err   error
+}
+
+func (e *PhoneError) Error() string {
+	return fmt.Sprintf("utility: %q: %v", e.Input, e.Err)
+}
+
+// Unwrap returns the underlying Err* value.
+func (e *PhoneError) Unwrap() error {
+	return e.Err
+}
+
+// PhoneNumber is a parsed phone number.
+type PhoneNumber struct {
+	// CountryCode is the country calling code without "+", e.g. "44".
+	CountryCode string
+	// National is the national significant number, without any trunk prefix.
+	National string
+	// Extension is the extension dialled after connecting, if any.
+	Extension string
+	// Region is the ISO 3166-1 region of the number, empty when it is not in the numbering plan table.
+	Region string
+}
+
+// E164 returns the number as "+<country code><national number>".
+func (p PhoneNumber) E164() string {
+	return "+" + p.CountryCode + p.National
+}
+
+// String ...
+func (p PhoneNumber) String() string {
+	return p.E164()
+}
+
+const (
+	// maxE164Digits is the longest number E.164 allows, country code included.
+	maxE164Digits = 15
+	// minNSNDigits is the shortest national significant number accepted for regions without metadata.
+	minNSNDigits = 4
+)
+
+var (
+	phoneExtension  = regexp.MustCompile(`(?i)\s*(?:,|;ext=|ext\.?|extension|x|#)\s*(\d{1,7})\s*$`)
+	phoneSeparators = strings.NewReplacer(" ", "", "\t", "", "(", "", ")", "", "-", "", ".", "", "/", "", "\u00a0", "")
+)
+
+// ParsePhone parses v as a phone number. Numbers starting with "+" or the
+// international call prefix of region are read as international, anything
+// else as a national number of region (DefaultPhoneRegion when blank).
+func ParsePhone(v interface{}, region string) (PhoneNumber, error) {
+	input := strings.TrimSpace(ToString(v))
+	if input == "" {
+		return PhoneNumber{}, &PhoneError{Input: input, Err: ErrPhoneBlank}
+	}
+	if region == "" {
+		region = DefaultPhoneRegion
+	}
+	region = strings.ToUpper(region)
+
+	var p PhoneNumber
+	number := input
+	if m := phoneExtension.FindStringSubmatchIndex(number); m != nil {
+		p.Extension = number[m[2]:m[3]]
+		number = number[:m[0]]
+	}
+	number = phoneSeparators.Replace(number)
+
+	international := strings.HasPrefix(number, "+")
+	number = strings.TrimPrefix(number, "+")
+	if !isDigits(number) {
+		return PhoneNumber{}, &PhoneError{Input: input, Err: ErrPhoneInvalidChars}
+	}
+
+	home, known := phoneRegions[region]
+	if !international && known && home.intl != "" && strings.HasPrefix(number, home.intl) {
+		international = true
+		number = number[len(home.intl):]
+	}
+	if !international && strings.HasPrefix(number, "00") {
+		international = true
+		number = number[2:]
+	}
+
+	var err error
+	if international {
+		p, err = parseInternational(p, number)
+	} else {
+		if !known {
+			return PhoneNumber{}, &PhoneError{Input: input, Err: ErrPhoneUnknownRegion}
+		}
+		p, err = parseNational(p, number, home)
+	}
+	if err != nil {
+		return PhoneNumber{}, &PhoneError{Input: input, Err: err}
+	}
+	return p, nil
+}
+
+// parseInternational fills p from digits that start with a country calling code.
+func parseInternational(p PhoneNumber, digits string) (PhoneNumber, error) {
+	code, nsn, ok := splitCallingCode(digits)
+	if !ok {
+		return p, ErrPhoneInvalidCountry
+	}
+	p.CountryCode = code
+	if r, ok := regionFor(code, nsn, ""); ok {
+		// "+44 (0)20 ..." is a common way of writing the trunk prefix that must be dropped.
+		if r.trunk != "" && r.trunk != code && strings.HasPrefix(nsn, r.trunk) {
+			nsn = nsn[len(r.trunk):]
+		}
+		if r, ok = regionFor(code, nsn, ""); ok {
+			p.Region = r.region
+		}
+	}
+	p.National = nsn
+	return p, checkPhoneLength(p)
+}
+
+// parseNational fills p from digits dialled inside region r.
+func parseNational(p PhoneNumber, digits string, r phoneRegion) (PhoneNumber, error) {
+	nsn := digits
+	switch {
+	case r.trunk != "" && strings.HasPrefix(nsn, r.trunk) && len(nsn)-len(r.trunk) >= r.minLen:
+		nsn = nsn[len(r.trunk):]
+	case len(nsn) > r.maxLen && strings.HasPrefix(nsn, r.code) &&
+		len(nsn)-len(r.code) >= r.minLen && len(nsn)-len(r.code) <= r.maxLen:
+		// The country code was written without "+", e.g. "447911123456".
+		nsn = nsn[len(r.code):]
+	}
+	p.CountryCode = r.code
+	p.National = nsn
+	p.Region = r.region
+	if actual, ok := regionFor(r.code, nsn, r.region); ok {
+		p.Region = actual.region
+	}
+	return p, checkPhoneLength(p)
+}
+
+// checkPhoneLength checks the national number length against the numbering
+// plan of its region, or the overall E.164 limits if the region is unknown.
+func checkPhoneLength(p PhoneNumber) error {
+	minLen, maxLen := minNSNDigits, maxE164Digits-len(p.CountryCode)
+	if r, ok := regionFor(p.CountryCode, p.National, p.Region); ok {
+		minLen, maxLen = r.minLen, r.maxLen
+	}
+	switch {
+	case len(p.National) < minLen:
+		return ErrPhoneTooShort
+	case len(p.National) > maxLen:
+		return ErrPhoneTooLong
+	}
+	return nil
+}
+
+// NormalizePhone returns v as a "+<country code><national number>" E.164
+// string, reading national numbers as numbers of region. Extensions are dropped.
+func NormalizePhone(v interface{}, region string) (string, error) {
+	p, err := ParsePhone(v, region)
+	if err != nil {
+		return "", err
+	}
+	return p.E164(), nil
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
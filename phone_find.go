@@ -0,0 +1,109 @@
+package utility
+
+import (
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PhoneMatch is a phone number found in free text.
+type PhoneMatch struct {
+	// Raw is the number as written, text[Start:End].
+	Raw string
+	// Start and End are the byte offsets of Raw in the text.
+	Start, End int
+	// Number is the parsed number.
+	Number PhoneNumber
+	// E164 is the number normalized by NormalizePhone, e.g. "+19700000987".
+	E164 string
+}
+
+// phoneCandidate matches runs of digit groups written with the usual separators,
+// an optional leading "+", bracketed area codes and a trailing extension.
+var phoneCandidate = regexp.MustCompile(
+	`(?i)(?:\+[ \t]?)?(?:\([ \t]?\d{1,5}[ \t]?\)[ \t.\-]?)?\d{1,5}(?:[ \t.\-/]?\(?\d{1,5}\)?){1,7}` +
+		`(?:[ \t]*(?:ext\.?|extension|x)[ \t]*\d{1,7})?`)
+
+// FindPhones returns the phone numbers in text, in order. National numbers are
+// read as numbers of region (DefaultPhoneRegion when blank). A candidate only
+// counts if it is a valid number of its country, so dates, order numbers and
+// short codes are skipped. When a run of digit groups is not a valid number,
+// its shorter prefixes are tried, so "415 555 2671 2 times" still finds the
+// number, and the search goes on after its first group.
+func FindPhones(text string, region string) []PhoneMatch {
+	var matches []PhoneMatch
+	for pos := 0; pos < len(text); {
+		loc := phoneCandidate.FindStringIndex(text[pos:])
+		if loc == nil {
+			break
+		}
+		start, end := pos+loc[0], pos+loc[1]
+		m, ok := findPhoneAt(text, start, end, region)
+		if !ok {
+			pos = groupEnd(text, start, end)
+			continue
+		}
+		matches = append(matches, m)
+		pos = m.End
+	}
+	return matches
+}
+
+// findPhoneAt returns the longest valid number in text[start:end] that starts
+// at start and ends at the end of a digit group.
+func findPhoneAt(text string, start, end int, region string) (PhoneMatch, bool) {
+	for e := end; e > start; e-- {
+		if !isDigitByte(text[e-1]) || (e < end && isDigitByte(text[e])) {
+			continue
+		}
+		if !phoneBoundary(text, start, e) {
+			continue
+		}
+		raw := text[start:e]
+		p, err := ParsePhone(raw, region)
+		if err != nil || !p.Validity().Valid() {
+			continue
+		}
+		return PhoneMatch{
+			Raw:    raw,
+			Start:  start,
+			End:    e,
+			Number: p,
+			E164:   p.E164(),
+		}, true
+	}
+	return PhoneMatch{}, false
+}
+
+// groupEnd returns the end of the first digit group in text[start:end].
+func groupEnd(text string, start, end int) int {
+	i := start
+	for i < end && !isDigitByte(text[i]) {
+		i++
+	}
+	for i < end && isDigitByte(text[i]) {
+		i++
+	}
+	return i
+}
+
+func isDigitByte(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// phoneBoundary reports whether text[start:end] is not glued to a word or another number.
+func phoneBoundary(text string, start, end int) bool {
+	if start > 0 {
+		r, _ := utf8.DecodeLastRuneInString(text[:start])
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '_' {
+			return false
+		}
+	}
+	if end < len(text) {
+		r, _ := utf8.DecodeRuneInString(text[end:])
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package utility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindPhones(t *testing.T) {
+	type args struct {
+		text   string
+		region string
+	}
+	type want struct {
+		raw  []string
+		e164 []string
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Common US Formats",
+			args: args{
+				text: "Call (970) 555-0987 or 970.555.1234, fax 1-970-555-5555.",
+			},
+			want: want{
+				raw:  []string{"(970) 555-0987", "970.555.1234", "1-970-555-5555"},
+				e164: []string{"+19705550987", "+19705551234", "+19705555555"},
+			},
+		},
+		{
+			name: "International And Extension",
+			args: args{
+				text: "UK office +44 (0)20 7946 0018, US desk +1 970 555 0987 ext. 12!",
+			},
+			want: want{
+				raw:  []string{"+44 (0)20 7946 0018", "+1 970 555 0987 ext. 12"},
+				e164: []string{"+442079460018", "+19705550987"},
+			},
+		},
+		{
+			name: "National Numbers Of Region",
+			args: args{
+				text:   "ring 07911 123456 after 5pm",
+				region: "GB",
+			},
+			want: want{
+				raw:  []string{"07911 123456"},
+				e164: []string{"+447911123456"},
+			},
+		},
+		{
+			name: "Number Followed By A Count",
+			args: args{
+				text:   "call 415-555-2671 2 times",
+				region: "US",
+			},
+			want: want{
+				raw:  []string{"415-555-2671"},
+				e164: []string{"+14155552671"},
+			},
+		},
+		{
+			name: "Numbers Separated By Spaces Only",
+			args: args{
+				text:   "415 555 2671 415 555 2672",
+				region: "US",
+			},
+			want: want{
+				raw:  []string{"415 555 2671", "415 555 2672"},
+				e164: []string{"+14155552671", "+14155552672"},
+			},
+		},
+		{
+			name: "Number After A Stray Digit",
+			args: args{
+				text: "room 2 415 555 2671",
+			},
+			want: want{
+				raw:  []string{"415 555 2671"},
+				e164: []string{"+14155552671"},
+			},
+		},
+		{
+			name: "Dates, Orders And Words Are Skipped",
+			args: args{
+				text: "Order #1001 shipped 2021-01-02, tracking ABC9700000987, code 74683.",
+			},
+			want: want{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var raw, e164 []string
+			for _, m := range FindPhones(tc.args.text, tc.args.region) {
+				assert.Equal(t, m.Raw, tc.args.text[m.Start:m.End])
+				raw = append(raw, m.Raw)
+				e164 = append(e164, m.E164)
+			}
+			assert.Equal(t, tc.want.raw, raw)
+			assert.Equal(t, tc.want.e164, e164)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package utility
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PhoneFormat is a display style for PhoneNumber.Format.
+type PhoneFormat int
+
+const (
+	// E164 formats as "+19700000987".
+	E164 PhoneFormat = iota
+	// International formats as "+1 (970) 000-0987" or "+44 20 7946 0018".
+	International
+	// National formats as dialled inside the region, e.g. "(970) 000-0987" or "020 7946 0018".
+	National
+	// RFC3966 formats as a tel: URI, e.g. "tel:+1-970-000-0987".
+	RFC3966
+)
+
+// Format returns the number in the given style using the grouping pattern of
+// its region. Numbers without a known pattern are shown ungrouped.
+func (p PhoneNumber) Format(style PhoneFormat) string {
+	r := phoneRegions[p.Region]
+	f, groups := r.format(p.National)
+
+	switch style {
+	case International:
+		s := "+" + p.CountryCode + " " + p.National
+		if f != nil {
+			s = "+" + p.CountryCode + " " + expandPhoneTemplate(f.intl, groups)
+		}
+		return s + p.extensionSuffix()
+	case National:
+		s := r.trunk + p.National
+		if f != nil {
+			s = expandPhoneTemplate(f.national, groups)
+		}
+		return s + p.extensionSuffix()
+	case RFC3966:
+		s := "tel:+" + p.CountryCode + "-" + p.National
+		if f != nil {
+			s = "tel:+" + p.CountryCode + "-" + strings.Join(groups, "-")
+		}
+		if p.Extension != "" {
+			s += ";ext=" + p.Extension
+		}
+		return s
+	}
+	return p.E164()
+}
+
+func (p PhoneNumber) extensionSuffix() string {
+	if p.Extension == "" {
+		return ""
+	}
+	return " ext. " + p.Extension
+}
+
+// format returns the first pattern of r that matches nsn, with nsn split into its groups.
+func (r phoneRegion) format(nsn string) (*phoneFormat, []string) {
+	for i := range r.formats {
+		f := &r.formats[i]
+		if f.length != 0 && f.length != len(nsn) {
+			continue
+		}
+		if len(f.prefixes) > 0 && !hasAnyPrefix(nsn, f.prefixes) {
+			continue
+		}
+		if groups, ok := splitPhoneGroups(nsn, f.groups); ok {
+			return f, groups
+		}
+	}
+	return nil, nil
+}
+
+// splitPhoneGroups cuts nsn into groups of the given sizes, a trailing 0 taking the rest.
+func splitPhoneGroups(nsn string, sizes []int) ([]string, bool) {
+	groups := make([]string, 0, len(sizes))
+	rest := nsn
+	for i, n := range sizes {
+		if n == 0 && i == len(sizes)-1 {
+			n = len(rest)
+		}
+		if n <= 0 || n > len(rest) {
+			return nil, false
+		}
+		groups = append(groups, rest[:n])
+		rest = rest[n:]
+	}
+	return groups, rest == ""
+}
+
+// expandPhoneTemplate replaces $1, $2, ... in tmpl with the groups.
+func expandPhoneTemplate(tmpl string, groups []string) string {
+	pairs := make([]string, 0, 2*len(groups))
+	for i := len(groups) - 1; i >= 0; i-- {
+		pairs = append(pairs, "$"+strconv.Itoa(i+1), groups[i])
+	}
+	return strings.NewReplacer(pairs...).Replace(tmpl)
+}
+
+// FormatPhoneAs parses v as a number of region and formats it in the given style.
+func FormatPhoneAs(v interface{}, region string, style PhoneFormat) (string, error) {
+	p, err := ParsePhone(v, region)
+	if err != nil {
+		return "", err
+	}
+	return p.Format(style), nil
+}
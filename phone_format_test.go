@@ -0,0 +1,136 @@
+package utility
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPhoneAs(t *testing.T) {
+	type args struct {
+		v      interface{}
+		region string
+		style  PhoneFormat
+	}
+	type want struct {
+		output string
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "US E164",
+			args: args{
+				v:      "(970) 000-0987",
+				region: "US",
+				style:  E164,
+			},
+			want: want{
+				output: "+19700000987",
+			},
+		},
+		{
+			name: "US National",
+			args: args{
+				v:      "19700000987",
+				region: "US",
+				style:  National,
+			},
+			want: want{
+				output: "(970) 000-0987",
+			},
+		},
+		{
+			name: "US RFC3966 With Extension",
+			args: args{
+				v:      "970-000-0987 x 12",
+				region: "US",
+				style:  RFC3966,
+			},
+			want: want{
+				output: "tel:+1-970-000-0987;ext=12",
+			},
+		},
+		{
+			name: "London National",
+			args: args{
+				v:      "+442079460018",
+				region: "US",
+				style:  National,
+			},
+			want: want{
+				output: "020 7946 0018",
+			},
+		},
+		{
+			name: "London International",
+			args: args{
+				v:      "020 7946 0018",
+				region: "GB",
+				style:  International,
+			},
+			want: want{
+				output: "+44 20 7946 0018",
+			},
+		},
+		{
+			name: "Australian Mobile National",
+			args: args{
+				v:     "+61412345678",
+				style: National,
+			},
+			want: want{
+				output: "0412 345 678",
+			},
+		},
+		{
+			name: "French International",
+			args: args{
+				v:      "01 23 45 67 89",
+				region: "FR",
+				style:  International,
+			},
+			want: want{
+				output: "+33 1 23 45 67 89",
+			},
+		},
+		{
+			name: "India International With Extension",
+			args: args{
+				v:      "09876543210 ext 7",
+				region: "IN",
+				style:  International,
+			},
+			want: want{
+				output: "+91 98765 43210 ext. 7",
+			},
+		},
+		{
+			name: "No Pattern Falls Back To Ungrouped",
+			args: args{
+				v:     "+3545512345",
+				style: International,
+			},
+			want: want{
+				output: "+354 5512345",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := FormatPhoneAs(tc.args.v, tc.args.region, tc.args.style)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want.output, output)
+		})
+	}
+}
+
+func TestFormatPhoneAsError(t *testing.T) {
+	_, err := FormatPhoneAs("not a phone", "US", National)
+	assert.True(t, errors.Is(err, ErrPhoneInvalidChars))
+}
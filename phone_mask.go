@@ -0,0 +1,148 @@
+package utility
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// MaskStyle selects how MaskPhone hides a phone number.
+type MaskStyle int
+
+const (
+	// MaskLast4 masks every digit but the last four: "+1 (970) 000-0987" => "+* (***) ***-0987".
+	MaskLast4 MaskStyle = iota
+	// MaskMiddle masks every digit but the first two and the last two: "+1 (970) 000-0987" => "+1 (9**) ***-**87".
+	MaskMiddle
+	// MaskHash replaces the number with the hex SHA-256 of its E.164 form, so equal numbers
+	// can still be matched without being shown. Phone numbers are easy to enumerate, so the
+	// hash is a pseudonym, not encryption.
+	MaskHash
+)
+
+// PhoneMaskChar replaces the hidden digits.
+const PhoneMaskChar = '*'
+
+// MaskPhone hides the digits of v according to style, keeping its formatting.
+func MaskPhone(v interface{}, style MaskStyle) string {
+	phone := ToString(v)
+	if phone == "" {
+		return ""
+	}
+	switch style {
+	case MaskHash:
+		return hashPhone(phone)
+	case MaskMiddle:
+		return maskDigits(phone, 2, 2)
+	}
+	return maskDigits(phone, 0, 4)
+}
+
+// maskDigits masks the digits of phone except the first keepHead and the last
+// keepTail. Numbers too short to keep anything are masked completely. An
+// extension is kept as written and does not count toward keepTail.
+func maskDigits(phone string, keepHead, keepTail int) string {
+	var ext string
+	if m := phoneExtension.FindStringIndex(phone); m != nil {
+		phone, ext = phone[:m[0]], phone[m[0]:]
+	}
+	total := countDigits(phone)
+	if total <= keepHead+keepTail {
+		keepHead, keepTail = 0, 0
+	}
+
+	var b strings.Builder
+	b.Grow(len(phone))
+	n := 0
+	for _, r := range phone {
+		if r < '0' || r > '9' {
+			b.WriteRune(r)
+			continue
+		}
+		if n < keepHead || n >= total-keepTail {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(PhoneMaskChar)
+		}
+		n++
+	}
+	b.WriteString(ext)
+	return b.String()
+}
+
+// countDigits returns the number of ASCII digits in s.
+func countDigits(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			n++
+		}
+	}
+	return n
+}
+
+// hashPhone hashes the E.164 form of phone, or its cleaned digits when it can't be parsed.
+func hashPhone(phone string) string {
+	return hashPhoneIn(phone, "")
+}
+
+// hashPhoneIn is hashPhone reading national numbers as numbers of region.
+func hashPhoneIn(phone, region string) string {
+	normalized, err := NormalizePhone(phone, region)
+	if err != nil {
+		normalized = CleanPhone(phone)
+	}
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// minRedactDigits is the fewest digits a phone-like run of text needs to be
+// masked by RedactPhones, the length of the shortest national numbers in the
+// region table.
+const minRedactDigits = 6
+
+// redactDate matches dates, which RedactPhones leaves alone.
+var redactDate = regexp.MustCompile(`^(?:\d{4}[-/.]\d{1,2}[-/.]\d{1,2}|\d{1,2}[-/.]\d{1,2}[-/.](?:\d{2}|\d{4}))$`)
+
+// RedactPhones masks every phone number in text. It fails closed: any run of
+// at least six digits written like a phone number is masked, even if it is
+// not a valid number or is glued to letters, so foreign numbers, typos and
+// numbers inside words are not leaked. Only dates such as "2024-01-15" and
+// numbers right after "#", such as "Order #1234567", are left alone. National
+// numbers are read as numbers of region (DefaultPhoneRegion when blank) for
+// MaskHash.
+func RedactPhones(text string, region string, style MaskStyle) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range phoneCandidate.FindAllStringIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		raw := text[start:end]
+		if countDigits(raw) < minRedactDigits || redactDate.MatchString(raw) ||
+			strings.HasSuffix(strings.TrimRight(text[:start], " \t"), "#") {
+			continue
+		}
+		b.WriteString(text[last:start])
+		if style == MaskHash {
+			b.WriteString(hashPhoneIn(raw, region))
+		} else {
+			b.WriteString(MaskPhone(raw, style))
+		}
+		last = end
+	}
+	if last == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// RedactForTarget masks every phone number in text with MaskLast4 when
+// ShowPhone(target) is false, and returns text unchanged otherwise. See
+// RedactPhones for what counts as a phone number.
+func RedactForTarget(target, text string) string {
+	if ShowPhone(target) {
+		return text
+	}
+	return RedactPhones(text, DefaultPhoneRegion, MaskLast4)
+}
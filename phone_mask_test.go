@@ -0,0 +1,212 @@
+package utility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskPhone(t *testing.T) {
+	type args struct {
+		v     interface{}
+		style MaskStyle
+	}
+	type want struct {
+		output string
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Last 4 Keeps Formatting",
+			args: args{
+				v:     "+1 (970) 555-0987",
+				style: MaskLast4,
+			},
+			want: want{
+				output: "+* (***) ***-0987",
+			},
+		},
+		{
+			name: "Last 4 Digits Only",
+			args: args{
+				v:     "19705550987",
+				style: MaskLast4,
+			},
+			want: want{
+				output: "*******0987",
+			},
+		},
+		{
+			name: "Middle",
+			args: args{
+				v:     "+1 (970) 555-0987",
+				style: MaskMiddle,
+			},
+			want: want{
+				output: "+1 (9**) ***-**87",
+			},
+		},
+		{
+			name: "Too Short To Keep Digits",
+			args: args{
+				v:     "0987",
+				style: MaskLast4,
+			},
+			want: want{
+				output: "****",
+			},
+		},
+		{
+			name: "Hash Of E164 Form",
+			args: args{
+				v:     "(970) 555-0987",
+				style: MaskHash,
+			},
+			want: want{
+				output: MaskPhone("+19705550987", MaskHash),
+			},
+		},
+		{
+			name: "Last 4 Ignores Extension",
+			args: args{
+				v:     "970-555-0987 ext. 1234",
+				style: MaskLast4,
+			},
+			want: want{
+				output: "***-***-0987 ext. 1234",
+			},
+		},
+		{
+			name: "Middle Ignores Extension",
+			args: args{
+				v:     "+1 970 555 0987 x12",
+				style: MaskMiddle,
+			},
+			want: want{
+				output: "+1 9** *** **87 x12",
+			},
+		},
+		{
+			name: "Blank",
+			args: args{
+				v:     nil,
+				style: MaskLast4,
+			},
+			want: want{
+				output: "",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output := MaskPhone(tc.args.v, tc.args.style)
+			assert.Equal(t, tc.want.output, output)
+		})
+	}
+
+	assert.Len(t, MaskPhone("19705550987", MaskHash), 64)
+}
+
+func TestRedactForTarget(t *testing.T) {
+	type args struct {
+		target string
+		text   string
+	}
+	type want struct {
+		output string
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Facebook Hides Numbers",
+			args: args{
+				target: "fb:page:user",
+				text:   "My number is (970) 555-0987, or +44 20 7946 0018.",
+			},
+			want: want{
+				output: "My number is (***) ***-0987, or +** ** **** 0018.",
+			},
+		},
+		{
+			name: "Facebook Hides Numbers That Are Not Valid",
+			args: args{
+				target: "fb:1:2",
+				text:   "Call me on 07911 123456 or 0000000000",
+			},
+			want: want{
+				output: "Call me on ***** **3456 or ******0000",
+			},
+		},
+		{
+			name: "Facebook Hides Numbers Glued To Words",
+			args: args{
+				target: "fb:1:2",
+				text:   "abc4155552671 / 4155552671ok",
+			},
+			want: want{
+				output: "abc******2671 / ******2671ok",
+			},
+		},
+		{
+			name: "Dates And Order Numbers Are Kept",
+			args: args{
+				target: "fb:1:2",
+				text:   "Order #1234567 on 2024-01-15, due 15/01/2024",
+			},
+			want: want{
+				output: "Order #1234567 on 2024-01-15, due 15/01/2024",
+			},
+		},
+		{
+			name: "SMS Shows Numbers",
+			args: args{
+				target: "19705550987",
+				text:   "My number is (970) 555-0987.",
+			},
+			want: want{
+				output: "My number is (970) 555-0987.",
+			},
+		},
+		{
+			name: "WhatsApp Shows Numbers",
+			args: args{
+				target: "whatsapp:acct:19705550987",
+				text:   "My number is (970) 555-0987.",
+			},
+			want: want{
+				output: "My number is (970) 555-0987.",
+			},
+		},
+		{
+			name: "Nothing To Redact",
+			args: args{
+				target: "abc:business:user",
+				text:   "Order 1001 is ready",
+			},
+			want: want{
+				output: "Order 1001 is ready",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output := RedactForTarget(tc.args.target, tc.args.text)
+			assert.Equal(t, tc.want.output, output)
+		})
+	}
+}
+
+func TestRedactPhonesHash(t *testing.T) {
+	output := RedactPhones("Call (970) 555-0987 or 0000000000.", "US", MaskHash)
+	assert.Equal(t, "Call "+MaskPhone("+19705550987", MaskHash)+" or "+MaskPhone("0000000000", MaskHash)+".", output)
+}
@@ -0,0 +1,217 @@
+package utility
+
+import "strings"
+
+// phoneRegion is the numbering plan metadata of one supported region.
+type phoneRegion struct {
+	region    string // ISO 3166-1 alpha-2 code
+	code      string // country calling code
+	trunk     string // national trunk prefix dialled before the NSN, if any
+	intl      string // international call prefix
+	minLen    int    // shortest national significant number
+	maxLen    int    // longest national significant number
+	areaCodes []string
+	formats   []phoneFormat
+}
+
+// phoneFormat groups the digits of a national significant number for display.
+// The national and intl templates refer to the groups as $1, $2, ...
+type phoneFormat struct {
+	prefixes []string // leading digits the format applies to, any when empty
+	length   int      // NSN length the format applies to, any when 0
+	groups   []int    // group sizes, a trailing 0 takes the remaining digits
+	national string
+	intl     string
+}
+
+// nanpFormats is shared by every region of the North American Numbering Plan.
+var nanpFormats = []phoneFormat{
+	{length: 10, groups: []int{3, 3, 4}, national: "($1) $2-$3", intl: "($1) $2-$3"},
+}
+
+// phoneRegions is the embedded numbering plan table keyed by region.
+var phoneRegions = map[string]phoneRegion{
+	"US": {region: "US", code: "1", trunk: "1", intl: "011", minLen: 10, maxLen: 10, formats: nanpFormats},
+	"CA": {
+		region: "CA", code: "1", trunk: "1", intl: "011", minLen: 10, maxLen: 10, formats: nanpFormats,
+		areaCodes: []string{
+			"204", "226", "236", "249", "250", "263", "289", "306", "343", "354", "365", "367", "368", "382",
+			"403", "416", "418", "428", "431", "437", "438", "450", "468", "474", "506", "514", "519", "548",
+			"579", "581", "584", "587", "604", "613", "639", "647", "672", "683", "705", "709", "742", "753",
+			"778", "780", "782", "807", "819", "825", "867", "873", "879", "902", "905",
+		},
+	},
+	"GB": {
+		region: "GB", code: "44", trunk: "0", intl: "00", minLen: 9, maxLen: 10,
+		formats: []phoneFormat{
+			{prefixes: []string{"20"}, length: 10, groups: []int{2, 4, 4}, national: "0$1 $2 $3", intl: "$1 $2 $3"},
+			{length: 10, groups: []int{4, 6}, national: "0$1 $2", intl: "$1 $2"},
+			{length: 9, groups: []int{4, 5}, national: "0$1 $2", intl: "$1 $2"},
+		},
+	},
+	"IE": {
+		region: "IE", code: "353", trunk: "0", intl: "00", minLen: 7, maxLen: 9,
+		formats: []phoneFormat{
+			{prefixes: []string{"8"}, length: 9, groups: []int{2, 3, 4}, national: "0$1 $2 $3", intl: "$1 $2 $3"},
+			{prefixes: []string{"1"}, length: 8, groups: []int{1, 3, 4}, national: "0$1 $2 $3", intl: "$1 $2 $3"},
+		},
+	},
+	"AU": {
+		region: "AU", code: "61", trunk: "0", intl: "0011", minLen: 9, maxLen: 9,
+		formats: []phoneFormat{
+			{prefixes: []string{"4"}, length: 9, groups: []int{3, 3, 3}, national: "0$1 $2 $3", intl: "$1 $2 $3"},
+			{length: 9, groups: []int{1, 4, 4}, national: "0$1 $2 $3", intl: "$1 $2 $3"},
+		},
+	},
+	"NZ": {
+		region: "NZ", code: "64", trunk: "0", intl: "00", minLen: 8, maxLen: 10,
+		formats: []phoneFormat{
+			{prefixes: []string{"2"}, groups: []int{2, 3, 0}, national: "0$1 $2 $3", intl: "$1 $2 $3"},
+			{length: 8, groups: []int{1, 3, 4}, national: "0$1 $2 $3", intl: "$1 $2 $3"},
+		},
+	},
+	"IN": {
+		region: "IN", code: "91", trunk: "0", intl: "00", minLen: 10, maxLen: 10,
+		formats: []phoneFormat{
+			{length: 10, groups: []int{5, 5}, national: "0$1 $2", intl: "$1 $2"},
+		},
+	},
+	"DE": {
+		region: "DE", code: "49", trunk: "0", intl: "00", minLen: 6, maxLen: 11,
+		formats: []phoneFormat{
+			{prefixes: []string{"15", "16", "17"}, groups: []int{3, 0}, national: "0$1 $2", intl: "$1 $2"},
+			{prefixes: []string{"30", "40", "69", "89"}, groups: []int{2, 0}, national: "0$1 $2", intl: "$1 $2"},
+		},
+	},
+	"FR": {
+		region: "FR", code: "33", trunk: "0", intl: "00", minLen: 9, maxLen: 9,
+		formats: []phoneFormat{
+			{length: 9, groups: []int{1, 2, 2, 2, 2}, national: "0$1 $2 $3 $4 $5", intl: "$1 $2 $3 $4 $5"},
+		},
+	},
+	"ES": {
+		region: "ES", code: "34", intl: "00", minLen: 9, maxLen: 9,
+		formats: []phoneFormat{
+			{length: 9, groups: []int{3, 3, 3}, national: "$1 $2 $3", intl: "$1 $2 $3"},
+		},
+	},
+	"IT": {
+		region: "IT", code: "39", intl: "00", minLen: 6, maxLen: 11,
+		formats: []phoneFormat{
+			{prefixes: []string{"3"}, length: 10, groups: []int{3, 3, 4}, national: "$1 $2 $3", intl: "$1 $2 $3"},
+			{prefixes: []string{"0"}, groups: []int{2, 0}, national: "$1 $2", intl: "$1 $2"},
+		},
+	},
+	"MX": {
+		region: "MX", code: "52", intl: "00", minLen: 10, maxLen: 10,
+		formats: []phoneFormat{
+			{prefixes: []string{"33", "55", "81"}, length: 10, groups: []int{2, 4, 4}, national: "$1 $2 $3", intl: "$1 $2 $3"},
+			{length: 10, groups: []int{3, 3, 4}, national: "$1 $2 $3", intl: "$1 $2 $3"},
+		},
+	},
+	"BR": {
+		region: "BR", code: "55", trunk: "0", intl: "00", minLen: 10, maxLen: 11,
+		formats: []phoneFormat{
+			{length: 11, groups: []int{2, 5, 4}, national: "($1) $2-$3", intl: "$1 $2-$3"},
+			{length: 10, groups: []int{2, 4, 4}, national: "($1) $2-$3", intl: "$1 $2-$3"},
+		},
+	},
+	"SG": {
+		region: "SG", code: "65", intl: "000", minLen: 8, maxLen: 8,
+		formats: []phoneFormat{
+			{length: 8, groups: []int{4, 4}, national: "$1 $2", intl: "$1 $2"},
+		},
+	},
+	"ZA": {
+		region: "ZA", code: "27", trunk: "0", intl: "00", minLen: 9, maxLen: 9,
+		formats: []phoneFormat{
+			{length: 9, groups: []int{2, 3, 4}, national: "0$1 $2 $3", intl: "$1 $2 $3"},
+		},
+	},
+	"PH": {
+		region: "PH", code: "63", trunk: "0", intl: "00", minLen: 10, maxLen: 10,
+		formats: []phoneFormat{
+			{length: 10, groups: []int{3, 3, 4}, national: "0$1 $2 $3", intl: "$1 $2 $3"},
+		},
+	},
+}
+
+// mainRegions is the region picked for a calling code shared by several regions.
+var mainRegions = map[string]string{
+	"1": "US",
+}
+
+// callingCodes is every assigned ITU-T E.164 country calling code. The codes
+// are prefix free, so the first match on the leading digits is the only one.
+var callingCodes = func() map[string]bool {
+	codes := map[string]bool{}
+	for _, c := range strings.Fields(`
+		1 7
+		20 27 30 31 32 33 34 36 39 40 41 43 44 45 46 47 48 49 51 52 53 54 55 56 57 58
+		60 61 62 63 64 65 66 81 82 84 86 90 91 92 93 94 95 98
+		211 212 213 216 218 220 221 222 223 224 225 226 227 228 229 230 231 232 233 234
+		235 236 237 238 239 240 241 242 243 244 245 246 247 248 249 250 251 252 253 254
+		255 256 257 258 260 261 262 263 264 265 266 267 268 269 290 291 297 298 299
+		350 351 352 353 354 355 356 357 358 359 370 371 372 373 374 375 376 377 378
+		380 381 382 383 385 386 387 389 420 421 423
+		500 501 502 503 504 505 506 507 508 509 590 591 592 593 594 595 596 597 598 599
+		670 672 673 674 675 676 677 678 679 680 681 682 683 685 686 687 688 689 690 691 692
+		800 808 850 852 853 855 856 870 878 880 881 882 883 886 888
+		960 961 962 963 964 965 966 967 968 970 971 972 973 974 975 976 977 979
+		992 993 994 995 996 998`) {
+		codes[c] = true
+	}
+	return codes
+}()
+
+// splitCallingCode splits digits into its country calling code and the rest.
+func splitCallingCode(digits string) (code, rest string, ok bool) {
+	for i := 1; i <= 3 && i <= len(digits); i++ {
+		if callingCodes[digits[:i]] {
+			return digits[:i], digits[i:], true
+		}
+	}
+	return "", digits, false
+}
+
+// regionFor returns the metadata of the region a national significant number
+// with the given calling code belongs to. Regions listing their own area codes
+// (e.g. Canada inside the NANP) win, then hint, then the main region of the code.
+func regionFor(code, nsn, hint string) (phoneRegion, bool) {
+	for _, r := range phoneRegions {
+		if r.code == code && len(r.areaCodes) > 0 && r.hasAreaCode(nsn) {
+			return r, true
+		}
+	}
+	if r, ok := phoneRegions[strings.ToUpper(hint)]; ok && r.code == code && len(r.areaCodes) == 0 {
+		return r, true
+	}
+	if main, ok := mainRegions[code]; ok {
+		return phoneRegions[main], true
+	}
+	for _, r := range phoneRegions {
+		if r.code == code {
+			return r, true
+		}
+	}
+	return phoneRegion{}, false
+}
+
+// hasAreaCode reports whether nsn starts with one of the region's own area
+// codes. Regions without an area code list accept every number.
+func (r phoneRegion) hasAreaCode(nsn string) bool {
+	if len(r.areaCodes) == 0 {
+		return true
+	}
+	return hasAnyPrefix(nsn, r.areaCodes)
+}
+
+// hasAnyPrefix reports whether s starts with any of prefixes.
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,243 @@
+package utility
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	type args struct {
+		v      interface{}
+		region string
+	}
+	type want struct {
+		output string
+		err    error
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "US National",
+			args: args{
+				v:      "(970) 000-0987",
+				region: "US",
+			},
+			want: want{
+				output: "+19700000987",
+			},
+		},
+		{
+			name: "US With Trunk Prefix And Default Region",
+			args: args{
+				v: "1-970-000-0987",
+			},
+			want: want{
+				output: "+19700000987",
+			},
+		},
+		{
+			name: "Canada National",
+			args: args{
+				v:      "416.555.0123",
+				region: "CA",
+			},
+			want: want{
+				output: "+14165550123",
+			},
+		},
+		{
+			name: "UK Trunk Prefix",
+			args: args{
+				v:      "07911 123456",
+				region: "GB",
+			},
+			want: want{
+				output: "+447911123456",
+			},
+		},
+		{
+			name: "UK International With Written Trunk Prefix",
+			args: args{
+				v:      "+44 (0)20 7946 0018",
+				region: "US",
+			},
+			want: want{
+				output: "+442079460018",
+			},
+		},
+		{
+			name: "India Trunk Prefix",
+			args: args{
+				v:      "098765 43210",
+				region: "in",
+			},
+			want: want{
+				output: "+919876543210",
+			},
+		},
+		{
+			name: "India Country Code Without Plus",
+			args: args{
+				v:      "919876543210",
+				region: "IN",
+			},
+			want: want{
+				output: "+919876543210",
+			},
+		},
+		{
+			name: "Australia From US International Prefix",
+			args: args{
+				v:      "011 61 412 345 678",
+				region: "US",
+			},
+			want: want{
+				output: "+61412345678",
+			},
+		},
+		{
+			name: "Australia International Prefix",
+			args: args{
+				v:      "0011 1 970 000 0987",
+				region: "AU",
+			},
+			want: want{
+				output: "+19700000987",
+			},
+		},
+		{
+			name: "Extension Is Stripped",
+			args: args{
+				v:      "+1 970-000-0987 ext. 204",
+				region: "US",
+			},
+			want: want{
+				output: "+19700000987",
+			},
+		},
+		{
+			name: "Blank",
+			args: args{
+				v: nil,
+			},
+			want: want{
+				err: ErrPhoneBlank,
+			},
+		},
+		{
+			name: "Letters",
+			args: args{
+				v: "abcdefghij",
+			},
+			want: want{
+				err: ErrPhoneInvalidChars,
+			},
+		},
+		{
+			name: "Unknown Calling Code",
+			args: args{
+				v: "+999 1234 5678",
+			},
+			want: want{
+				err: ErrPhoneInvalidCountry,
+			},
+		},
+		{
+			name: "Unknown Region",
+			args: args{
+				v:      "1234567",
+				region: "ZZ",
+			},
+			want: want{
+				err: ErrPhoneUnknownRegion,
+			},
+		},
+		{
+			name: "Too Long",
+			args: args{
+				v: "+44 1234 5678 9012 345",
+			},
+			want: want{
+				err: ErrPhoneTooLong,
+			},
+		},
+		{
+			name: "Too Short",
+			args: args{
+				v: "+44 12",
+			},
+			want: want{
+				err: ErrPhoneTooShort,
+			},
+		},
+		{
+			name: "Too Short For Region",
+			args: args{
+				v:      "12345",
+				region: "US",
+			},
+			want: want{
+				err: ErrPhoneTooShort,
+			},
+		},
+		{
+			name: "Trunk Prefix Kept When Too Short For Region",
+			args: args{
+				v:      "020 7946",
+				region: "GB",
+			},
+			want: want{
+				err: ErrPhoneTooShort,
+			},
+		},
+		{
+			name: "Foreign Number Too Long For Region",
+			args: args{
+				v:      "442079460018",
+				region: "US",
+			},
+			want: want{
+				err: ErrPhoneTooLong,
+			},
+		},
+		{
+			name: "International Too Long For Region",
+			args: args{
+				v: "+1 970 000 09871",
+			},
+			want: want{
+				err: ErrPhoneTooLong,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := NormalizePhone(tc.args.v, tc.args.region)
+			assert.True(t, errors.Is(err, tc.want.err), "got error %v", err)
+			assert.Equal(t, tc.want.output, output)
+		})
+	}
+}
+
+func TestParsePhone(t *testing.T) {
+	p, err := ParsePhone("+1 (416) 555-0123 x12", "")
+	assert.NoError(t, err)
+	assert.Equal(t, PhoneNumber{CountryCode: "1", National: "4165550123", Extension: "12", Region: "CA"}, p)
+
+	p, err = ParsePhone("0412 345 678", "AU")
+	assert.NoError(t, err)
+	assert.Equal(t, "AU", p.Region)
+	assert.Equal(t, "+61412345678", p.String())
+
+	_, err = ParsePhone("12ab34", "")
+	var perr *PhoneError
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, "12ab34", perr.Input)
+}
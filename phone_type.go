@@ -0,0 +1,191 @@
+package utility
+
+// PhoneType is the kind of line a phone number belongs to.
+type PhoneType int
+
+const (
+	// PhoneTypeUnknown the number could not be classified.
+	PhoneTypeUnknown PhoneType = iota
+	// PhoneTypeMobile a mobile number.
+	PhoneTypeMobile
+	// PhoneTypeFixedLine a landline number.
+	PhoneTypeFixedLine
+	// PhoneTypeFixedOrMobile a number from a plan that does not tell mobile and landline apart, like the NANP.
+	PhoneTypeFixedOrMobile
+	// PhoneTypeTollFree a toll-free number such as 800 or 0800.
+	PhoneTypeTollFree
+	// PhoneTypePremiumRate a premium-rate number such as 900.
+	PhoneTypePremiumRate
+	// PhoneTypeShortCode a US/CA SMS short code of 5 or 6 digits.
+	PhoneTypeShortCode
+)
+
+var phoneTypeNames = [...]string{
+	PhoneTypeUnknown:       "unknown",
+	PhoneTypeMobile:        "mobile",
+	PhoneTypeFixedLine:     "fixed line",
+	PhoneTypeFixedOrMobile: "fixed line or mobile",
+	PhoneTypeTollFree:      "toll free",
+	PhoneTypePremiumRate:   "premium rate",
+	PhoneTypeShortCode:     "short code",
+}
+
+func (t PhoneType) String() string {
+	if t < 0 || int(t) >= len(phoneTypeNames) {
+		return phoneTypeNames[PhoneTypeUnknown]
+	}
+	return phoneTypeNames[t]
+}
+
+// CanReceiveSMS reports whether numbers of this type can usually receive text
+// messages. Fixed-or-mobile numbers are included, since most of them are mobile.
+func (t PhoneType) CanReceiveSMS() bool {
+	switch t {
+	case PhoneTypeMobile, PhoneTypeFixedOrMobile, PhoneTypeShortCode:
+		return true
+	}
+	return false
+}
+
+// phoneTypeRanges lists the leading digits of the national significant numbers of each type.
+type phoneTypeRanges struct {
+	tollFree []string
+	premium  []string
+	mobile   []string
+	fixed    []string
+	// ambiguous is set for plans where numbers outside the other ranges may be mobile or fixed.
+	ambiguous bool
+}
+
+var nanpTypeRanges = phoneTypeRanges{
+	tollFree:  []string{"800", "833", "844", "855", "866", "877", "888"},
+	premium:   []string{"900"},
+	ambiguous: true,
+}
+
+// phoneTypes is the embedded number range table keyed by region.
+var phoneTypes = map[string]phoneTypeRanges{
+	"US": nanpTypeRanges,
+	"CA": nanpTypeRanges,
+	"GB": {
+		tollFree: []string{"800", "808"},
+		premium:  []string{"9"},
+		mobile:   []string{"71", "72", "73", "74", "75", "77", "78", "79"},
+		fixed:    []string{"1", "2"},
+	},
+	"IE": {
+		tollFree: []string{"1800"},
+		premium:  []string{"15"},
+		mobile:   []string{"83", "85", "86", "87", "89"},
+		fixed:    []string{"1", "2", "4", "5", "6", "7", "9"},
+	},
+	"AU": {
+		tollFree: []string{"180"},
+		premium:  []string{"19"},
+		mobile:   []string{"4"},
+		fixed:    []string{"2", "3", "7", "8"},
+	},
+	"NZ": {
+		tollFree: []string{"800", "508"},
+		premium:  []string{"900"},
+		mobile:   []string{"2"},
+		fixed:    []string{"3", "4", "6", "7", "9"},
+	},
+	"IN": {
+		tollFree: []string{"1800"},
+		mobile:   []string{"6", "7", "8", "9"},
+		fixed:    []string{"1", "2", "3", "4", "5"},
+	},
+	"DE": {
+		tollFree: []string{"800"},
+		premium:  []string{"900"},
+		mobile:   []string{"15", "16", "17"},
+		fixed:    []string{"2", "3", "4", "5", "6", "7", "8", "9"},
+	},
+	"FR": {
+		tollFree: []string{"80"},
+		premium:  []string{"89"},
+		mobile:   []string{"6", "7"},
+		fixed:    []string{"1", "2", "3", "4", "5", "9"},
+	},
+	"ES": {
+		tollFree: []string{"900"},
+		premium:  []string{"803", "806", "807", "905"},
+		mobile:   []string{"6", "7"},
+		fixed:    []string{"8", "9"},
+	},
+	"IT": {
+		tollFree: []string{"800", "803"},
+		premium:  []string{"89"},
+		mobile:   []string{"3"},
+		fixed:    []string{"0"},
+	},
+	"MX": {
+		tollFree:  []string{"800"},
+		premium:   []string{"900"},
+		ambiguous: true,
+	},
+	"BR": {
+		ambiguous: true,
+	},
+	"SG": {
+		tollFree: []string{"1800"},
+		premium:  []string{"1900"},
+		mobile:   []string{"8", "9"},
+		fixed:    []string{"6"},
+	},
+	"ZA": {
+		tollFree: []string{"80"},
+		premium:  []string{"86"},
+		mobile:   []string{"6", "7", "81", "82", "83", "84"},
+		fixed:    []string{"1", "2", "3", "4", "5"},
+	},
+	"PH": {
+		tollFree: []string{"1800"},
+		mobile:   []string{"9"},
+		fixed:    []string{"2", "3", "4", "5", "6", "7", "8"},
+	},
+}
+
+// Type classifies the number by the number ranges of its region.
+func (p PhoneNumber) Type() PhoneType {
+	ranges, ok := phoneTypes[p.Region]
+	if !ok {
+		return PhoneTypeUnknown
+	}
+	switch nsn := p.National; {
+	case hasAnyPrefix(nsn, ranges.tollFree):
+		return PhoneTypeTollFree
+	case hasAnyPrefix(nsn, ranges.premium):
+		return PhoneTypePremiumRate
+	case hasAnyPrefix(nsn, ranges.mobile):
+		return PhoneTypeMobile
+	case hasAnyPrefix(nsn, ranges.fixed):
+		return PhoneTypeFixedLine
+	case ranges.ambiguous:
+		return PhoneTypeFixedOrMobile
+	}
+	return PhoneTypeUnknown
+}
+
+// PhoneTypeOf classifies v after cleaning it with CleanPhone. 5 and 6 digit
+// numbers are US/CA short codes, anything else is completed by E164Phone and
+// must then be a valid number to be classified.
+func PhoneTypeOf(v interface{}) PhoneType {
+	phone := CleanPhone(v)
+	if !isDigits(phone) {
+		return PhoneTypeUnknown
+	}
+	if len(phone) == 5 || len(phone) == 6 {
+		return PhoneTypeShortCode
+	}
+	phone = E164Phone(phone)
+	if !ValidatePhone(phone).Valid() {
+		return PhoneTypeUnknown
+	}
+	p, err := ParsePhone("+"+phone, "")
+	if err != nil {
+		return PhoneTypeUnknown
+	}
+	return p.Type()
+}
@@ -0,0 +1,139 @@
+package utility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoneTypeOf(t *testing.T) {
+	type args struct {
+		v interface{}
+	}
+	type want struct {
+		output PhoneType
+		sms    bool
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "US Short Code",
+			args: args{
+				v: "74683",
+			},
+			want: want{
+				output: PhoneTypeShortCode,
+				sms:    true,
+			},
+		},
+		{
+			name: "US Six Digit Short Code",
+			args: args{
+				v: "898-211",
+			},
+			want: want{
+				output: PhoneTypeShortCode,
+				sms:    true,
+			},
+		},
+		{
+			name: "US Toll Free",
+			args: args{
+				v: "(833) 555-0100",
+			},
+			want: want{
+				output: PhoneTypeTollFree,
+				sms:    false,
+			},
+		},
+		{
+			name: "US Premium Rate",
+			args: args{
+				v: "1-900-555-0100",
+			},
+			want: want{
+				output: PhoneTypePremiumRate,
+				sms:    false,
+			},
+		},
+		{
+			name: "US Geographic",
+			args: args{
+				v: "9705550987",
+			},
+			want: want{
+				output: PhoneTypeFixedOrMobile,
+				sms:    true,
+			},
+		},
+		{
+			name: "UK Mobile",
+			args: args{
+				v: "+44 7911 123456",
+			},
+			want: want{
+				output: PhoneTypeMobile,
+				sms:    true,
+			},
+		},
+		{
+			name: "UK Landline",
+			args: args{
+				v: "+44 20 7946 0018",
+			},
+			want: want{
+				output: PhoneTypeFixedLine,
+				sms:    false,
+			},
+		},
+		{
+			name: "Australia Mobile",
+			args: args{
+				v: "61412345678",
+			},
+			want: want{
+				output: PhoneTypeMobile,
+				sms:    true,
+			},
+		},
+		{
+			name: "Bad Number",
+			args: args{
+				v: "12345678",
+			},
+			want: want{
+				output: PhoneTypeUnknown,
+				sms:    false,
+			},
+		},
+		{
+			name: "Blank",
+			args: args{
+				v: "",
+			},
+			want: want{
+				output: PhoneTypeUnknown,
+				sms:    false,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output := PhoneTypeOf(tc.args.v)
+			assert.Equal(t, tc.want.output, output)
+			assert.Equal(t, tc.want.sms, output.CanReceiveSMS())
+		})
+	}
+}
+
+func TestPhoneNumberType(t *testing.T) {
+	p, err := ParsePhone("0800 123 4567", "GB")
+	assert.NoError(t, err)
+	assert.Equal(t, PhoneTypeTollFree, p.Type())
+	assert.Equal(t, "toll free", p.Type().String())
+}
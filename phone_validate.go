@@ -0,0 +1,91 @@
+package utility
+
+import "strings"
+
+// PhoneValidity is the outcome of ValidatePhone.
+type PhoneValidity int
+
+const (
+	// PhoneIsValid the number is plausible for its country.
+	PhoneIsValid PhoneValidity = iota
+	// PhoneIsBlank the number is empty.
+	PhoneIsBlank
+	// PhoneInvalidCharacters the number contains something other than digits.
+	PhoneInvalidCharacters
+	// PhoneInvalidCountryCode the number does not start with an assigned country calling code.
+	PhoneInvalidCountryCode
+	// PhoneTooShort the national number is shorter than its country allows.
+	PhoneTooShort
+	// PhoneTooLong the national number is longer than its country allows.
+	PhoneTooLong
+	// PhoneInvalidNumber the number has the right length but is not possible
+	// in its country, e.g. a NANP area code starting with 0 or 1.
+	PhoneInvalidNumber
+)
+
+var phoneValidityNames = [...]string{
+	PhoneIsValid:            "valid",
+	PhoneIsBlank:            "blank",
+	PhoneInvalidCharacters:  "invalid characters",
+	PhoneInvalidCountryCode: "invalid country code",
+	PhoneTooShort:           "too short",
+	PhoneTooLong:            "too long",
+	PhoneInvalidNumber:      "invalid number",
+}
+
+func (v PhoneValidity) String() string {
+	if v < 0 || int(v) >= len(phoneValidityNames) {
+		return "unknown"
+	}
+	return phoneValidityNames[v]
+}
+
+// Valid reports whether v is PhoneIsValid.
+func (v PhoneValidity) Valid() bool {
+	return v == PhoneIsValid
+}
+
+// ValidatePhone checks a number in the form CleanPhone and E164Phone return
+// it: digits starting with the country calling code, optionally with a leading
+// "+". The national number length is checked against the numbering plan of
+// its country, or the E.164 limits for countries not in the table. NANP
+// numbers (country code 1) must also have an area code and exchange starting
+// with 2-9.
+func ValidatePhone(v interface{}) PhoneValidity {
+	phone := strings.TrimPrefix(strings.TrimSpace(ToString(v)), "+")
+	if phone == "" {
+		return PhoneIsBlank
+	}
+	if !isDigits(phone) {
+		return PhoneInvalidCharacters
+	}
+	code, nsn, ok := splitCallingCode(phone)
+	if !ok {
+		return PhoneInvalidCountryCode
+	}
+
+	minLen, maxLen := minNSNDigits, maxE164Digits-len(code)
+	if r, ok := regionFor(code, nsn, ""); ok {
+		minLen, maxLen = r.minLen, r.maxLen
+	}
+	switch {
+	case len(nsn) < minLen:
+		return PhoneTooShort
+	case len(nsn) > maxLen:
+		return PhoneTooLong
+	case code == "1" && !nanpValid(nsn):
+		return PhoneInvalidNumber
+	}
+	return PhoneIsValid
+}
+
+// nanpValid reports whether the 10 digit NANP number nsn has an area code and
+// an exchange that both start with 2-9.
+func nanpValid(nsn string) bool {
+	return len(nsn) == 10 && nsn[0] >= '2' && nsn[3] >= '2'
+}
+
+// Validity validates the number against the numbering plan of its country.
+func (p PhoneNumber) Validity() PhoneValidity {
+	return ValidatePhone(p.E164())
+}
@@ -0,0 +1,127 @@
+package utility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePhone(t *testing.T) {
+	type args struct {
+		v interface{}
+	}
+	type want struct {
+		output PhoneValidity
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "US From E164Phone",
+			args: args{
+				v: E164Phone("9705550987"),
+			},
+			want: want{
+				output: PhoneIsValid,
+			},
+		},
+		{
+			name: "UK With Plus",
+			args: args{
+				v: "+447911123456",
+			},
+			want: want{
+				output: PhoneIsValid,
+			},
+		},
+		{
+			name: "Country Without Metadata",
+			args: args{
+				v: "3545512345",
+			},
+			want: want{
+				output: PhoneIsValid,
+			},
+		},
+		{
+			name: "Nil",
+			args: args{
+				v: nil,
+			},
+			want: want{
+				output: PhoneIsBlank,
+			},
+		},
+		{
+			name: "Formatted Input",
+			args: args{
+				v: "1 (970) 000-0987",
+			},
+			want: want{
+				output: PhoneInvalidCharacters,
+			},
+		},
+		{
+			name: "Unassigned Country Code",
+			args: args{
+				v: "99912345678",
+			},
+			want: want{
+				output: PhoneInvalidCountryCode,
+			},
+		},
+		{
+			name: "US Too Short",
+			args: args{
+				v: "1970000098",
+			},
+			want: want{
+				output: PhoneTooShort,
+			},
+		},
+		{
+			name: "NANP Area Code Starting With 1",
+			args: args{
+				v: "11235550987",
+			},
+			want: want{
+				output: PhoneInvalidNumber,
+			},
+		},
+		{
+			name: "NANP Exchange Starting With 0",
+			args: args{
+				v: "19700000987",
+			},
+			want: want{
+				output: PhoneInvalidNumber,
+			},
+		},
+		{
+			name: "India Too Long",
+			args: args{
+				v: "9198765432101",
+			},
+			want: want{
+				output: PhoneTooLong,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output := ValidatePhone(tc.args.v)
+			assert.Equal(t, tc.want.output, output)
+			assert.Equal(t, tc.want.output == PhoneIsValid, output.Valid())
+		})
+	}
+}
+
+func TestPhoneValidityString(t *testing.T) {
+	assert.Equal(t, "too short", PhoneTooShort.String())
+	assert.Equal(t, "invalid number", PhoneInvalidNumber.String())
+	assert.Equal(t, "unknown", PhoneValidity(42).String())
+}
@@ -0,0 +1,321 @@
+package utility
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPath is returned for a path that can't be parsed.
+	ErrInvalidPath = errors.New("invalid path")
+	// ErrPathNotFound is returned when a path does not exist.
+	ErrPathNotFound = errors.New("path not found")
+	// ErrPathType is returned when a path goes through a value that is not a map or a slice.
+	ErrPathType = errors.New("path goes through a value that is not a map or a slice")
+)
+
+// PathError reports a failed Prop path operation.
+type PathError struct {
+	Path string
+	Err  error
+}
+
+func (e *PathError) Error() string {
+	return fmt.Sprintf("utility: path %q: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// pathToken is one step of a path, a map key or a slice index.
+type pathToken struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parsePath splits a path like "customer.phones[0].number" into its steps.
+// Keys can't contain ".", "[" or "]".
+func parsePath(path string) ([]pathToken, error) {
+	if path == "" {
+		return nil, ErrInvalidPath
+	}
+	var tokens []pathToken
+	for _, segment := range strings.Split(path, ".") {
+		key := segment
+		if i := strings.IndexByte(segment, '['); i >= 0 {
+			key = segment[:i]
+			segment = segment[i:]
+		} else {
+			segment = ""
+		}
+		if key == "" || strings.ContainsAny(key, "[]") {
+			return nil, ErrInvalidPath
+		}
+		tokens = append(tokens, pathToken{key: key})
+		for segment != "" {
+			end := strings.IndexByte(segment, ']')
+			if segment[0] != '[' || end < 0 {
+				return nil, ErrInvalidPath
+			}
+			n, err := strconv.Atoi(segment[1:end])
+			if err != nil || n < 0 {
+				return nil, ErrInvalidPath
+			}
+			tokens = append(tokens, pathToken{index: n, isIndex: true})
+			segment = segment[end+1:]
+		}
+	}
+	return tokens, nil
+}
+
+// Get returns the value at path, e.g. "customer.phones[0].number". Maps are
+// entered by key and slices by index.
+func (p Prop) Get(path string) (interface{}, error) {
+	tokens, err := parsePath(path)
+	if err != nil {
+		return nil, &PathError{Path: path, Err: err}
+	}
+	var cur interface{} = map[string]interface{}(p)
+	for _, tok := range tokens {
+		next, err := step(cur, tok)
+		if err != nil {
+			return nil, &PathError{Path: path, Err: err}
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
+// step returns the child of container selected by tok.
+func step(container interface{}, tok pathToken) (interface{}, error) {
+	switch c := container.(type) {
+	case map[string]interface{}:
+		if tok.isIndex {
+			return nil, ErrPathType
+		}
+		v, ok := c[tok.key]
+		if !ok {
+			return nil, ErrPathNotFound
+		}
+		return v, nil
+	case Prop:
+		return step(map[string]interface{}(c), tok)
+	case []interface{}:
+		if !tok.isIndex {
+			return nil, ErrPathType
+		}
+		if tok.index >= len(c) {
+			return nil, ErrPathNotFound
+		}
+		return c[tok.index], nil
+	}
+
+	rv := reflect.ValueOf(container)
+	switch {
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String && !tok.isIndex:
+		v := rv.MapIndex(reflect.ValueOf(tok.key).Convert(rv.Type().Key()))
+		if !v.IsValid() {
+			return nil, ErrPathNotFound
+		}
+		return v.Interface(), nil
+	case (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && tok.isIndex:
+		if tok.index >= rv.Len() {
+			return nil, ErrPathNotFound
+		}
+		return rv.Index(tok.index).Interface(), nil
+	}
+	return nil, ErrPathType
+}
+
+// Has reports whether path exists.
+func (p Prop) Has(path string) bool {
+	_, err := p.Get(path)
+	return err == nil
+}
+
+// PropGet returns the value at path converted to T with To.
+func PropGet[T any](p Prop, path string) (T, error) {
+	var zero T
+	v, err := p.Get(path)
+	if err != nil {
+		return zero, err
+	}
+	out, err := To[T](v)
+	if err != nil {
+		return zero, &PathError{Path: path, Err: err}
+	}
+	return out, nil
+}
+
+// PropGetOr returns the value at path converted to T, or def if it is missing or can't be converted.
+func PropGetOr[T any](p Prop, path string, def T) T {
+	out, err := PropGet[T](p, path)
+	if err != nil {
+		return def
+	}
+	return out
+}
+
+// GetString returns the value at path as a string.
+func (p Prop) GetString(path string) (string, error) {
+	return PropGet[string](p, path)
+}
+
+// GetInt returns the value at path as an int.
+func (p Prop) GetInt(path string) (int, error) {
+	return PropGet[int](p, path)
+}
+
+// GetInt64 returns the value at path as an int64.
+func (p Prop) GetInt64(path string) (int64, error) {
+	return PropGet[int64](p, path)
+}
+
+// GetFloat64 returns the value at path as a float64.
+func (p Prop) GetFloat64(path string) (float64, error) {
+	return PropGet[float64](p, path)
+}
+
+// GetBool returns the value at path as a bool.
+func (p Prop) GetBool(path string) (bool, error) {
+	return PropGet[bool](p, path)
+}
+
+// GetMap returns the object at path as a Prop. The Prop shares the underlying map.
+func (p Prop) GetMap(path string) (Prop, error) {
+	v, err := p.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	m, err := AsMap(v)
+	if err != nil {
+		return nil, &PathError{Path: path, Err: errors.Unwrap(err)}
+	}
+	return m, nil
+}
+
+// GetSlice returns the array at path as a []interface{}.
+func (p Prop) GetSlice(path string) ([]interface{}, error) {
+	return PropGet[[]interface{}](p, path)
+}
+
+// Set stores v at path, creating missing maps on the way, and slices for
+// indexed steps. Slices grow with nil elements up to the index being set.
+func (p Prop) Set(path string, v interface{}) error {
+	tokens, err := parsePath(path)
+	if err == nil && p == nil {
+		err = ErrPathType
+	}
+	if err != nil {
+		return &PathError{Path: path, Err: err}
+	}
+	if _, err := setIn(map[string]interface{}(p), tokens, v); err != nil {
+		return &PathError{Path: path, Err: err}
+	}
+	return nil
+}
+
+// setIn stores v below container and returns the container to store in its parent.
+func setIn(container interface{}, tokens []pathToken, v interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return v, nil
+	}
+	tok := tokens[0]
+
+	if !tok.isIndex {
+		var m map[string]interface{}
+		switch c := container.(type) {
+		case nil:
+			m = map[string]interface{}{}
+		case map[string]interface{}:
+			m = c
+		case Prop:
+			m = c
+		default:
+			return nil, ErrPathType
+		}
+		if m == nil {
+			m, container = map[string]interface{}{}, nil
+		}
+		child, err := setIn(m[tok.key], tokens[1:], v)
+		if err != nil {
+			return nil, err
+		}
+		m[tok.key] = child
+		if container == nil {
+			return m, nil
+		}
+		return container, nil
+	}
+
+	var s []interface{}
+	switch c := container.(type) {
+	case nil:
+	case []interface{}:
+		s = c
+	default:
+		return nil, ErrPathType
+	}
+	for len(s) <= tok.index {
+		s = append(s, nil)
+	}
+	child, err := setIn(s[tok.index], tokens[1:], v)
+	if err != nil {
+		return nil, err
+	}
+	s[tok.index] = child
+	return s, nil
+}
+
+// Delete removes the value at path, a map key or a slice element. It reports
+// whether anything was removed.
+func (p Prop) Delete(path string) bool {
+	tokens, err := parsePath(path)
+	if err != nil || p == nil {
+		return false
+	}
+	_, ok := deleteIn(map[string]interface{}(p), tokens)
+	return ok
+}
+
+// deleteIn removes the value below container and returns the container to store in its parent.
+func deleteIn(container interface{}, tokens []pathToken) (interface{}, bool) {
+	tok := tokens[0]
+	switch c := container.(type) {
+	case Prop:
+		return deleteIn(map[string]interface{}(c), tokens)
+	case map[string]interface{}:
+		child, ok := c[tok.key]
+		if tok.isIndex || !ok {
+			return container, false
+		}
+		if len(tokens) == 1 {
+			delete(c, tok.key)
+			return c, true
+		}
+		child, ok = deleteIn(child, tokens[1:])
+		if ok {
+			c[tok.key] = child
+		}
+		return c, ok
+	case []interface{}:
+		if !tok.isIndex || tok.index >= len(c) {
+			return container, false
+		}
+		if len(tokens) == 1 {
+			return append(c[:tok.index:tok.index], c[tok.index+1:]...), true
+		}
+		child, ok := deleteIn(c[tok.index], tokens[1:])
+		if ok {
+			c[tok.index] = child
+		}
+		return c, ok
+	}
+	return container, false
+}
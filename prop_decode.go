@@ -0,0 +1,420 @@
+package utility
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FieldError is the failure to decode one field.
+type FieldError struct {
+	// Field is the path of the field, e.g. "customer.phones[0].number".
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError lists every field Prop.Decode failed to decode.
+type DecodeError struct {
+	Errors []*FieldError
+}
+
+func (e *DecodeError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return fmt.Sprintf("utility: decoding %d field(s) failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// propField is a struct field and the key it maps to.
+type propField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// structFields returns the fields of t by key. The key comes from the `prop`
+// tag, then the `json` tag, then the field name; "-" skips a field. Fields of
+// embedded structs are promoted unless the embedded struct is tagged, and a
+// shallower field wins over a deeper one with the same key.
+func structFields(t reflect.Type) []propField {
+	var fields []propField
+	seen := map[string]bool{}
+
+	type embedded struct {
+		t     reflect.Type
+		index []int
+	}
+	level := []embedded{{t: t}}
+	// visited stops the walk at a struct embedding itself, e.g. through a pointer.
+	visited := map[reflect.Type]bool{}
+	for len(level) > 0 {
+		var next []embedded
+		names := map[string]int{}
+		var found []propField
+		for _, e := range level {
+			if visited[e.t] {
+				continue
+			}
+			for i := 0; i < e.t.NumField(); i++ {
+				sf := e.t.Field(i)
+				name, opts, tagged := fieldTag(sf)
+				if name == "-" {
+					continue
+				}
+				index := append(append([]int(nil), e.index...), i)
+				ft := sf.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if sf.Anonymous && !tagged && ft.Kind() == reflect.Struct {
+					next = append(next, embedded{t: ft, index: index})
+					continue
+				}
+				if !sf.IsExported() {
+					continue
+				}
+				if name == "" {
+					name = sf.Name
+				}
+				names[name]++
+				found = append(found, propField{name: name, index: index, omitEmpty: strings.Contains(opts, "omitempty")})
+			}
+		}
+		for _, f := range found {
+			// Ambiguous keys at the same depth are dropped, like encoding/json does.
+			if !seen[f.name] && names[f.name] == 1 {
+				fields = append(fields, f)
+			}
+		}
+		for name := range names {
+			seen[name] = true
+		}
+		// Marked after the whole level, so a struct embedded twice at the
+		// same depth still makes its keys ambiguous.
+		for _, e := range level {
+			visited[e.t] = true
+		}
+		level = next
+	}
+	sort.Slice(fields, func(i, j int) bool { return lessIndex(fields[i].index, fields[j].index) })
+	return fields
+}
+
+func lessIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// fieldTag returns the key and options of the `prop` or `json` tag of sf.
+func fieldTag(sf reflect.StructField) (name, opts string, tagged bool) {
+	tag, ok := sf.Tag.Lookup("prop")
+	if !ok {
+		tag, ok = sf.Tag.Lookup("json")
+	}
+	if !ok {
+		return "", "", false
+	}
+	name, opts, _ = strings.Cut(tag, ",")
+	return name, opts, name != ""
+}
+
+// Decode stores p in the struct, map or slice dst points to. Keys are matched
+// to fields as json does, with the `prop` tag taking precedence over the
+// `json` tag, and case-insensitively if there is no exact match. Values are
+// converted weakly with To, so "5" fills an int and "2021-06-01" a time.Time.
+// Every field that fails is listed in the returned *DecodeError; the other
+// fields are still filled.
+func (p Prop) Decode(dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("utility: Decode needs a non-nil pointer, got %T", dst)
+	}
+	var errs []*FieldError
+	decodeValue("", map[string]interface{}(p), rv.Elem(), &errs)
+	if len(errs) > 0 {
+		return &DecodeError{Errors: errs}
+	}
+	return nil
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func decodeValue(path string, src interface{}, dst reflect.Value, errs *[]*FieldError) {
+	fail := func(err error) {
+		field := strings.TrimPrefix(path, ".")
+		if field == "" {
+			field = "."
+		}
+		*errs = append(*errs, &FieldError{Field: field, Err: err})
+	}
+	if src == nil {
+		return
+	}
+	t := dst.Type()
+
+	switch {
+	case t == timeType || t == durationType:
+	case t.Kind() == reflect.Ptr:
+		elem := reflect.New(t.Elem())
+		if !dst.IsNil() {
+			elem = dst
+		}
+		before := len(*errs)
+		decodeValue(path, src, elem.Elem(), errs)
+		if len(*errs) == before {
+			dst.Set(elem)
+		}
+		return
+	case reflect.PtrTo(t).Implements(jsonUnmarshalerType):
+		raw, err := json.Marshal(src)
+		if err == nil {
+			err = dst.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(raw)
+		}
+		if err != nil {
+			fail(err)
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t == timeType {
+			break
+		}
+		m, ok := asObject(src)
+		if !ok {
+			fail(ErrWrongType)
+			return
+		}
+		for _, f := range structFields(t) {
+			v, ok := lookupKey(m, f.name)
+			if !ok {
+				continue
+			}
+			field, err := fieldByIndexAlloc(dst, f.index)
+			if err != nil {
+				fail(err)
+				continue
+			}
+			decodeValue(path+"."+f.name, v, field, errs)
+		}
+		return
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		s, ok := src.([]interface{})
+		if !ok {
+			break
+		}
+		out := reflect.MakeSlice(t, len(s), len(s))
+		for i, v := range s {
+			decodeValue(fmt.Sprintf("%s[%d]", path, i), v, out.Index(i), errs)
+		}
+		dst.Set(out)
+		return
+	case reflect.Map:
+		m, ok := asObject(src)
+		if !ok {
+			break
+		}
+		out := reflect.MakeMapWithSize(t, len(m))
+		for k, v := range m {
+			key, err := convertTo(k, t.Key())
+			if err != nil {
+				fail(fmt.Errorf("key %q: %w", k, err))
+				continue
+			}
+			elem := reflect.New(t.Elem()).Elem()
+			decodeValue(path+"."+k, v, elem, errs)
+			out.SetMapIndex(key, elem)
+		}
+		dst.Set(out)
+		return
+	}
+
+	v, err := convertTo(src, t)
+	if err != nil {
+		fail(err)
+		return
+	}
+	dst.Set(v)
+}
+
+// lookupKey finds key in m, falling back to a case-insensitive match.
+func lookupKey(m map[string]interface{}, key string) (interface{}, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// fieldByIndexAlloc is reflect.Value.FieldByIndex, allocating nil embedded struct pointers.
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, errors.New("can't set embedded pointer to unexported struct")
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// PropFrom encodes the struct or map v as a Prop, using the same keys as
+// Prop.Decode. Nested structs and maps become map[string]interface{} and
+// slices []interface{}; times, json.Marshaler values and scalars are kept
+// as they are. Fields tagged omitempty are left out when empty.
+func PropFrom(v interface{}) (Prop, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, convError("PropFrom", v, ErrBlank)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct && rv.Kind() != reflect.Map {
+		return nil, convError("PropFrom", v, ErrWrongType)
+	}
+	out, err := encodeValue(rv)
+	if err != nil {
+		return nil, convError("PropFrom", v, err)
+	}
+	m, _ := asObject(out)
+	return m, nil
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+func encodeValue(v reflect.Value) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Implements(jsonMarshalerType) {
+			return v.Interface(), nil
+		}
+		return encodeValue(v.Elem())
+	}
+	if v.Type() == timeType || v.Type().Implements(jsonMarshalerType) {
+		return v.Interface(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		out := map[string]interface{}{}
+		for _, f := range structFields(v.Type()) {
+			fv, ok := fieldByIndexNoAlloc(v, f.index)
+			if !ok || (f.omitEmpty && isEmptyValue(fv)) {
+				continue
+			}
+			e, err := encodeValue(fv)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", f.name, err)
+			}
+			out[f.name] = e
+		}
+		return out, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			k, err := toString(iter.Key().Interface())
+			if err != nil {
+				return nil, fmt.Errorf("key %v: %w", iter.Key(), err)
+			}
+			e, err := encodeValue(iter.Value())
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			out[k] = e
+		}
+		return out, nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && (v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8) {
+			return v.Interface(), nil
+		}
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			e, err := encodeValue(v.Index(i))
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			out[i] = e
+		}
+		return out, nil
+	case reflect.Chan, reflect.Func, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return nil, ErrWrongType
+	}
+	if !v.CanInterface() {
+		return nil, ErrWrongType
+	}
+	return v.Interface(), nil
+}
+
+// fieldByIndexNoAlloc is reflect.Value.FieldByIndex, reporting false for a nil embedded pointer.
+func fieldByIndexNoAlloc(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// isEmptyValue reports whether v is empty as defined by json's omitempty.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
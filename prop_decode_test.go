@@ -0,0 +1,137 @@
+package utility
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAudit struct {
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+}
+
+type testPhone struct {
+	Number  string `json:"number"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type testCustomer struct {
+	testAudit
+	Name    string            `json:"name"`
+	Age     int               `json:"age"`
+	VIP     bool              `prop:"vip" json:"is_vip"`
+	Phones  []testPhone       `json:"phones"`
+	Limits  map[string]int    `json:"limits,omitempty"`
+	Timeout time.Duration     `json:"timeout,omitempty"`
+	Manager *testPhone        `json:"manager,omitempty"`
+	Extra   map[string]string `json:"-"`
+	secret  string
+}
+
+func TestPropDecode(t *testing.T) {
+	p := Prop{
+		"name":       "Deepak",
+		"Age":        "31",
+		"vip":        true,
+		"created_at": "2021-06-01T12:30:00Z",
+		"phones":     []interface{}{map[string]interface{}{"number": 19700000987, "primary": "true"}},
+		"limits":     map[string]interface{}{"daily": 10.0},
+		"timeout":    "5s",
+		"manager":    map[string]interface{}{"number": "19700001234"},
+		"Extra":      map[string]interface{}{"ignored": "yes"},
+	}
+
+	var c testCustomer
+	assert.NoError(t, p.Decode(&c))
+	assert.Equal(t, "Deepak", c.Name)
+	assert.Equal(t, 31, c.Age)
+	assert.True(t, c.VIP)
+	assert.True(t, time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC).Equal(c.CreatedAt))
+	assert.Equal(t, []testPhone{{Number: "19700000987", Primary: true}}, c.Phones)
+	assert.Equal(t, map[string]int{"daily": 10}, c.Limits)
+	assert.Equal(t, 5*time.Second, c.Timeout)
+	assert.Equal(t, &testPhone{Number: "19700001234"}, c.Manager)
+	assert.Nil(t, c.Extra)
+
+	assert.Error(t, p.Decode(c))
+}
+
+func TestPropDecodeErrors(t *testing.T) {
+	p := Prop{
+		"name":       "Deepak",
+		"age":        "thirty",
+		"created_at": "yesterday",
+		"phones":     []interface{}{map[string]interface{}{"number": "1", "primary": "maybe"}},
+	}
+
+	var c testCustomer
+	err := p.Decode(&c)
+	var de *DecodeError
+	assert.True(t, errors.As(err, &de))
+	assert.Len(t, de.Errors, 3)
+
+	fields := map[string]error{}
+	for _, fe := range de.Errors {
+		fields[fe.Field] = fe
+	}
+	assert.True(t, errors.Is(fields["age"], ErrSyntax))
+	assert.True(t, errors.Is(fields["created_at"], ErrSyntax))
+	assert.True(t, errors.Is(fields["phones[0].primary"], ErrSyntax))
+	assert.Equal(t, "Deepak", c.Name)
+}
+
+func TestPropFrom(t *testing.T) {
+	created := time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC)
+	c := testCustomer{
+		testAudit: testAudit{CreatedAt: created},
+		Name:      "Deepak",
+		Age:       31,
+		VIP:       true,
+		Phones:    []testPhone{{Number: "19700000987"}},
+		Extra:     map[string]string{"ignored": "yes"},
+		secret:    "x",
+	}
+
+	p, err := PropFrom(&c)
+	assert.NoError(t, err)
+	assert.Equal(t, Prop{
+		"created_at": created,
+		"name":       "Deepak",
+		"age":        31,
+		"vip":        true,
+		"phones":     []interface{}{map[string]interface{}{"number": "19700000987"}},
+	}, p)
+
+	var back testCustomer
+	assert.NoError(t, p.Decode(&back))
+	c.Extra, c.secret = nil, ""
+	assert.Equal(t, c, back)
+
+	p, err = PropFrom(map[string]int{"a": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, Prop{"a": 1}, p)
+
+	_, err = PropFrom("text")
+	assert.True(t, errors.Is(err, ErrWrongType))
+
+	_, err = PropFrom((*testCustomer)(nil))
+	assert.True(t, errors.Is(err, ErrBlank))
+}
+
+type testNode struct {
+	*testNode
+	X int `json:"x"`
+}
+
+func TestPropDecodeSelfEmbedding(t *testing.T) {
+	var n testNode
+	assert.NoError(t, Prop{"x": 1}.Decode(&n))
+	assert.Equal(t, 1, n.X)
+
+	p, err := PropFrom(&n)
+	assert.NoError(t, err)
+	assert.Equal(t, Prop{"x": 1}, p)
+}
@@ -0,0 +1,209 @@
+package utility
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MergeStrategy decides what Prop.Merge does when both sides have a key.
+// Nested objects are always merged key by key.
+type MergeStrategy int
+
+const (
+	// MergeOverwrite takes the value of the other Prop.
+	MergeOverwrite MergeStrategy = iota
+	// MergeKeep keeps the existing value, only missing keys are added.
+	MergeKeep
+	// MergeAppend concatenates two arrays and otherwise behaves like MergeOverwrite.
+	MergeAppend
+)
+
+// Merge returns a deep copy of p with other merged into it. Neither p nor
+// other is modified.
+func (p Prop) Merge(other Prop, strategy MergeStrategy) Prop {
+	out := deepCopy(map[string]interface{}(p)).(map[string]interface{})
+	if out == nil {
+		out = map[string]interface{}{}
+	}
+	mergeInto(out, other, strategy)
+	return out
+}
+
+func mergeInto(dst, src map[string]interface{}, strategy MergeStrategy) {
+	for k, sv := range src {
+		dv, exists := dst[k]
+		if !exists {
+			dst[k] = deepCopy(sv)
+			continue
+		}
+		dm, dIsMap := asObject(dv)
+		sm, sIsMap := asObject(sv)
+		ds, dIsSlice := dv.([]interface{})
+		ss, sIsSlice := sv.([]interface{})
+		switch {
+		case dIsMap && sIsMap:
+			mergeInto(dm, sm, strategy)
+		case strategy == MergeKeep:
+		case strategy == MergeAppend && dIsSlice && sIsSlice:
+			dst[k] = append(ds[:len(ds):len(ds)], deepCopy(ss).([]interface{})...)
+		default:
+			dst[k] = deepCopy(sv)
+		}
+	}
+}
+
+// asObject returns v as a map if it is a JSON object.
+func asObject(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, m != nil
+	case Prop:
+		return m, m != nil
+	}
+	return nil, false
+}
+
+// deepCopy copies the maps and slices of a decoded JSON value.
+func deepCopy(v interface{}) interface{} {
+	switch c := v.(type) {
+	case map[string]interface{}:
+		if c == nil {
+			return c
+		}
+		out := make(map[string]interface{}, len(c))
+		for k, e := range c {
+			out[k] = deepCopy(e)
+		}
+		return out
+	case Prop:
+		return Prop(deepCopy(map[string]interface{}(c)).(map[string]interface{}))
+	case []interface{}:
+		if c == nil {
+			return c
+		}
+		out := make([]interface{}, len(c))
+		for i, e := range c {
+			out[i] = deepCopy(e)
+		}
+		return out
+	}
+	return v
+}
+
+// ChangeOp is the kind of a Change.
+type ChangeOp string
+
+const (
+	// ChangeAdd the path only exists in the other Prop.
+	ChangeAdd ChangeOp = "add"
+	// ChangeRemove the path only exists in this Prop.
+	ChangeRemove ChangeOp = "remove"
+	// ChangeReplace the path has a different value in the other Prop.
+	ChangeReplace ChangeOp = "replace"
+)
+
+// Change is one difference found by Prop.Diff. A []Change marshals to a valid
+// RFC 6902 JSON Patch that turns one Prop into the other.
+type Change struct {
+	Op ChangeOp `json:"op"`
+	// Path is an RFC 6901 JSON Pointer, e.g. "/customer/phones/0".
+	Path string `json:"path"`
+	// Value is the new value, nil for ChangeRemove.
+	Value interface{} `json:"value"`
+	// Old is the previous value, unset for ChangeAdd.
+	Old interface{} `json:"old,omitempty"`
+}
+
+// Diff returns the changes that turn p into other, sorted by path. Objects
+// are compared key by key; arrays that differ are replaced as a whole.
+// Numbers compare by value, so int 1 equals float64 1.
+func (p Prop) Diff(other Prop) []Change {
+	changes := []Change{}
+	diffObjects("", p, other, &changes)
+	return changes
+}
+
+func diffObjects(prefix string, a, b map[string]interface{}, changes *[]Change) {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		path := prefix + "/" + escapePointer(k)
+		av, inA := a[k]
+		bv, inB := b[k]
+		am, aIsMap := asObject(av)
+		bm, bIsMap := asObject(bv)
+		switch {
+		case !inB:
+			*changes = append(*changes, Change{Op: ChangeRemove, Path: path, Old: av})
+		case !inA:
+			*changes = append(*changes, Change{Op: ChangeAdd, Path: path, Value: bv})
+		case aIsMap && bIsMap:
+			diffObjects(path, am, bm, changes)
+		case !valuesEqual(av, bv):
+			*changes = append(*changes, Change{Op: ChangeReplace, Path: path, Value: bv, Old: av})
+		}
+	}
+}
+
+// valuesEqual compares two decoded JSON values, treating every numeric kind alike.
+func valuesEqual(a, b interface{}) bool {
+	if am, ok := asObject(a); ok {
+		bm, ok := asObject(b)
+		if !ok || len(am) != len(bm) {
+			return false
+		}
+		for k, av := range am {
+			bv, ok := bm[k]
+			if !ok || !valuesEqual(av, bv) {
+				return false
+			}
+		}
+		return true
+	}
+	if as, ok := a.([]interface{}); ok {
+		bs, ok := b.([]interface{})
+		if !ok || len(as) != len(bs) {
+			return false
+		}
+		for i := range as {
+			if !valuesEqual(as[i], bs[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if isNumber(a) && isNumber(b) {
+		af, aerr := toFloat64(a)
+		bf, berr := toFloat64(b)
+		return aerr == nil && berr == nil && af == bf
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isNumber(v interface{}) bool {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.String:
+		_, ok := v.(interface{ Float64() (float64, error) })
+		return ok
+	}
+	return false
+}
+
+// escapePointer escapes a key for use in a JSON Pointer.
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,127 @@
+package utility
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropMerge(t *testing.T) {
+	base := Prop{
+		"name":  "Deepak",
+		"tags":  []interface{}{"a"},
+		"shop":  map[string]interface{}{"id": 1, "plan": "basic"},
+		"phone": "19700000987",
+	}
+	other := Prop{
+		"name": "Deepak P",
+		"tags": []interface{}{"b"},
+		"shop": map[string]interface{}{"plan": "pro", "currency": "USD"},
+		"page": "fb-page",
+	}
+
+	type args struct {
+		strategy MergeStrategy
+	}
+	type want struct {
+		output Prop
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Overwrite",
+			args: args{
+				strategy: MergeOverwrite,
+			},
+			want: want{
+				output: Prop{
+					"name":  "Deepak P",
+					"tags":  []interface{}{"b"},
+					"shop":  map[string]interface{}{"id": 1, "plan": "pro", "currency": "USD"},
+					"phone": "19700000987",
+					"page":  "fb-page",
+				},
+			},
+		},
+		{
+			name: "Keep",
+			args: args{
+				strategy: MergeKeep,
+			},
+			want: want{
+				output: Prop{
+					"name":  "Deepak",
+					"tags":  []interface{}{"a"},
+					"shop":  map[string]interface{}{"id": 1, "plan": "basic", "currency": "USD"},
+					"phone": "19700000987",
+					"page":  "fb-page",
+				},
+			},
+		},
+		{
+			name: "Append",
+			args: args{
+				strategy: MergeAppend,
+			},
+			want: want{
+				output: Prop{
+					"name":  "Deepak P",
+					"tags":  []interface{}{"a", "b"},
+					"shop":  map[string]interface{}{"id": 1, "plan": "pro", "currency": "USD"},
+					"phone": "19700000987",
+					"page":  "fb-page",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output := base.Merge(other, tc.args.strategy)
+			assert.Equal(t, tc.want.output, output)
+		})
+	}
+
+	// Inputs are left untouched.
+	assert.Equal(t, "basic", base["shop"].(map[string]interface{})["plan"])
+	assert.Equal(t, []interface{}{"a"}, base["tags"])
+	assert.Equal(t, Prop{"a": 1}, Prop(nil).Merge(Prop{"a": 1}, MergeKeep))
+}
+
+func TestPropDiff(t *testing.T) {
+	a := Prop{
+		"name":   "Deepak",
+		"count":  1,
+		"shop":   map[string]interface{}{"plan": "basic", "id": 7},
+		"tags":   []interface{}{"a"},
+		"old/id": "x",
+	}
+	b := Prop{
+		"name":  "Deepak",
+		"count": 1.0,
+		"shop":  map[string]interface{}{"plan": "pro", "id": 7},
+		"tags":  []interface{}{"a", "b"},
+		"page":  "fb",
+	}
+
+	changes := a.Diff(b)
+	assert.Equal(t, []Change{
+		{Op: ChangeRemove, Path: "/old~1id", Old: "x"},
+		{Op: ChangeAdd, Path: "/page", Value: "fb"},
+		{Op: ChangeReplace, Path: "/shop/plan", Value: "pro", Old: "basic"},
+		{Op: ChangeReplace, Path: "/tags", Value: []interface{}{"a", "b"}, Old: []interface{}{"a"}},
+	}, changes)
+	assert.Empty(t, a.Diff(a))
+
+	// The changes are a JSON Patch from a to b.
+	patch, err := json.Marshal(changes)
+	assert.NoError(t, err)
+	patched, err := a.ApplyPatch(patch)
+	assert.NoError(t, err)
+	assert.Empty(t, patched.Diff(b))
+}
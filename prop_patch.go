@@ -0,0 +1,311 @@
+package utility
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPatch is returned for a patch document that is not valid.
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrPatchTestFailed is returned when a JSON Patch "test" operation does not match.
+	ErrPatchTestFailed = errors.New("patch test failed")
+)
+
+// PatchError reports the JSON Patch operation that failed.
+type PatchError struct {
+	Index int // position of the operation in the patch
+	Op    string
+	Path  string
+	Err   error
+}
+
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("utility: patch operation %d (%s %q): %v", e.Index, e.Op, e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PatchError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyPatch applies an RFC 6902 JSON Patch document and returns the result.
+// The patch is applied to a copy, so p is left untouched when it fails.
+func (p Prop) ApplyPatch(patch []byte) (Prop, error) {
+	var ops []map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("utility: %w: %v", ErrInvalidPatch, err)
+	}
+
+	var doc interface{} = deepCopy(map[string]interface{}(p))
+	for i, op := range ops {
+		var name, path string
+		err := decodePatchMember(op, "op", &name)
+		if err == nil {
+			err = decodePatchMember(op, "path", &path)
+		}
+		if err == nil {
+			doc, err = applyPatchOp(doc, name, path, op)
+		}
+		if err != nil {
+			return nil, &PatchError{Index: i, Op: name, Path: path, Err: err}
+		}
+	}
+
+	out, ok := asObject(doc)
+	if !ok {
+		return nil, fmt.Errorf("utility: %w: result is not an object", ErrInvalidPatch)
+	}
+	return out, nil
+}
+
+// decodePatchMember decodes the required member name of op into v.
+func decodePatchMember(op map[string]json.RawMessage, name string, v interface{}) error {
+	raw, ok := op[name]
+	if !ok {
+		return fmt.Errorf("%w: missing %q", ErrInvalidPatch, name)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("%w: %q: %v", ErrInvalidPatch, name, err)
+	}
+	return nil
+}
+
+func applyPatchOp(doc interface{}, name, path string, op map[string]json.RawMessage) (interface{}, error) {
+	keys, err := parsePointer(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case "add", "replace", "test":
+		var value interface{}
+		if err := decodePatchMember(op, "value", &value); err != nil {
+			return nil, err
+		}
+		switch name {
+		case "add":
+			return pointerAdd(doc, keys, value)
+		case "replace":
+			return pointerReplace(doc, keys, value)
+		}
+		current, err := pointerGet(doc, keys)
+		if err != nil {
+			return nil, err
+		}
+		if !valuesEqual(current, value) {
+			return nil, ErrPatchTestFailed
+		}
+		return doc, nil
+	case "remove":
+		return pointerRemove(doc, keys)
+	case "move", "copy":
+		var from string
+		if err := decodePatchMember(op, "from", &from); err != nil {
+			return nil, err
+		}
+		fromKeys, err := parsePointer(from)
+		if err != nil {
+			return nil, err
+		}
+		value, err := pointerGet(doc, fromKeys)
+		if err != nil {
+			return nil, err
+		}
+		if name == "copy" {
+			return pointerAdd(doc, keys, deepCopy(value))
+		}
+		if path == from {
+			return doc, nil
+		}
+		if strings.HasPrefix(path, from+"/") {
+			return nil, fmt.Errorf("%w: can't move %q into itself", ErrInvalidPatch, from)
+		}
+		if doc, err = pointerRemove(doc, fromKeys); err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, keys, value)
+	}
+	return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, name)
+}
+
+// pointerGet returns the value keys point at.
+func pointerGet(doc interface{}, keys []string) (interface{}, error) {
+	for _, key := range keys {
+		var err error
+		if doc, err = pointerChild(doc, key); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// pointerChild returns the member key of an object or array.
+func pointerChild(container interface{}, key string) (interface{}, error) {
+	if m, ok := asObject(container); ok {
+		v, ok := m[key]
+		if !ok {
+			return nil, ErrPathNotFound
+		}
+		return v, nil
+	}
+	if s, ok := container.([]interface{}); ok {
+		i, err := arrayIndex(key, len(s))
+		if err != nil {
+			return nil, err
+		}
+		return s[i], nil
+	}
+	return nil, ErrPathType
+}
+
+// pointerUpdate calls fn with the parent of the last key and stores the
+// container it returns, which may be a new slice, back into the document.
+func pointerUpdate(doc interface{}, keys []string, fn func(parent interface{}, key string) (interface{}, error)) (interface{}, error) {
+	if len(keys) == 1 {
+		return fn(doc, keys[0])
+	}
+	child, err := pointerChild(doc, keys[0])
+	if err != nil {
+		return nil, err
+	}
+	child, err = pointerUpdate(child, keys[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := asObject(doc); ok {
+		m[keys[0]] = child
+	} else {
+		s := doc.([]interface{})
+		i, _ := arrayIndex(keys[0], len(s))
+		s[i] = child
+	}
+	return doc, nil
+}
+
+func pointerAdd(doc interface{}, keys []string, value interface{}) (interface{}, error) {
+	if len(keys) == 0 {
+		return value, nil
+	}
+	return pointerUpdate(doc, keys, func(parent interface{}, key string) (interface{}, error) {
+		if m, ok := asObject(parent); ok {
+			m[key] = value
+			return parent, nil
+		}
+		s, ok := parent.([]interface{})
+		if !ok {
+			return nil, ErrPathType
+		}
+		if key == "-" {
+			return append(s, value), nil
+		}
+		i, err := arrayIndex(key, len(s)+1)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, nil)
+		copy(s[i+1:], s[i:])
+		s[i] = value
+		return s, nil
+	})
+}
+
+func pointerReplace(doc interface{}, keys []string, value interface{}) (interface{}, error) {
+	if len(keys) == 0 {
+		return value, nil
+	}
+	return pointerUpdate(doc, keys, func(parent interface{}, key string) (interface{}, error) {
+		if _, err := pointerChild(parent, key); err != nil {
+			return nil, err
+		}
+		if m, ok := asObject(parent); ok {
+			m[key] = value
+			return parent, nil
+		}
+		s := parent.([]interface{})
+		i, _ := arrayIndex(key, len(s))
+		s[i] = value
+		return s, nil
+	})
+}
+
+func pointerRemove(doc interface{}, keys []string) (interface{}, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: can't remove the whole document", ErrInvalidPatch)
+	}
+	return pointerUpdate(doc, keys, func(parent interface{}, key string) (interface{}, error) {
+		if _, err := pointerChild(parent, key); err != nil {
+			return nil, err
+		}
+		if m, ok := asObject(parent); ok {
+			delete(m, key)
+			return parent, nil
+		}
+		s := parent.([]interface{})
+		i, _ := arrayIndex(key, len(s))
+		return append(s[:i], s[i+1:]...), nil
+	})
+}
+
+// ApplyMergePatch applies an RFC 7396 JSON Merge Patch document and returns
+// the result: objects are merged recursively and null removes a key.
+func (p Prop) ApplyMergePatch(patch []byte) (Prop, error) {
+	var doc interface{}
+	if err := json.Unmarshal(patch, &doc); err != nil {
+		return nil, fmt.Errorf("utility: %w: %v", ErrInvalidPatch, err)
+	}
+	out, ok := asObject(mergePatch(deepCopy(map[string]interface{}(p)), doc))
+	if !ok {
+		return nil, fmt.Errorf("utility: %w: result is not an object", ErrInvalidPatch)
+	}
+	return out, nil
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	pm, ok := asObject(patch)
+	if !ok {
+		return patch
+	}
+	tm, ok := asObject(target)
+	if !ok {
+		tm = map[string]interface{}{}
+	}
+	for k, v := range pm {
+		if v == nil {
+			delete(tm, k)
+			continue
+		}
+		tm[k] = mergePatch(tm[k], v)
+	}
+	return tm
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into its unescaped keys.
+func parsePointer(ptr string) ([]string, error) {
+	if ptr == "" {
+		return nil, nil
+	}
+	if ptr[0] != '/' {
+		return nil, ErrInvalidPath
+	}
+	keys := strings.Split(ptr[1:], "/")
+	for i, k := range keys {
+		keys[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(k)
+	}
+	return keys, nil
+}
+
+// arrayIndex parses a JSON Pointer array index, which has no sign or leading zeros.
+func arrayIndex(key string, length int) (int, error) {
+	if (len(key) > 1 && key[0] == '0') || !isDigits(key) {
+		return 0, ErrInvalidPath
+	}
+	i, err := strconv.Atoi(key)
+	if err != nil || i >= length {
+		return 0, ErrPathNotFound
+	}
+	return i, nil
+}
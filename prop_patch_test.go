@@ -0,0 +1,154 @@
+package utility
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropApplyPatch(t *testing.T) {
+	doc := Prop{
+		"name": "Deepak",
+		"tags": []interface{}{"a", "c"},
+		"shop": map[string]interface{}{"plan": "basic"},
+	}
+
+	type args struct {
+		patch string
+	}
+	type want struct {
+		output Prop
+		err    error
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Add Replace Remove",
+			args: args{
+				patch: `[
+					{"op": "add", "path": "/tags/1", "value": "b"},
+					{"op": "add", "path": "/tags/-", "value": "d"},
+					{"op": "replace", "path": "/shop/plan", "value": "pro"},
+					{"op": "remove", "path": "/name"}
+				]`,
+			},
+			want: want{
+				output: Prop{
+					"tags": []interface{}{"a", "b", "c", "d"},
+					"shop": map[string]interface{}{"plan": "pro"},
+				},
+			},
+		},
+		{
+			name: "Move Copy Test",
+			args: args{
+				patch: `[
+					{"op": "test", "path": "/shop/plan", "value": "basic"},
+					{"op": "copy", "from": "/tags", "path": "/labels"},
+					{"op": "move", "from": "/name", "path": "/shop/owner"}
+				]`,
+			},
+			want: want{
+				output: Prop{
+					"tags":   []interface{}{"a", "c"},
+					"labels": []interface{}{"a", "c"},
+					"shop":   map[string]interface{}{"plan": "basic", "owner": "Deepak"},
+				},
+			},
+		},
+		{
+			name: "Failed Test",
+			args: args{
+				patch: `[{"op": "test", "path": "/name", "value": "Someone"}]`,
+			},
+			want: want{
+				err: ErrPatchTestFailed,
+			},
+		},
+		{
+			name: "Replace Missing",
+			args: args{
+				patch: `[{"op": "replace", "path": "/email", "value": "a@b.c"}]`,
+			},
+			want: want{
+				err: ErrPathNotFound,
+			},
+		},
+		{
+			name: "Index Out Of Range",
+			args: args{
+				patch: `[{"op": "add", "path": "/tags/5", "value": "x"}]`,
+			},
+			want: want{
+				err: ErrPathNotFound,
+			},
+		},
+		{
+			name: "Unknown Op",
+			args: args{
+				patch: `[{"op": "merge", "path": "/name"}]`,
+			},
+			want: want{
+				err: ErrInvalidPatch,
+			},
+		},
+		{
+			name: "Missing Value",
+			args: args{
+				patch: `[{"op": "add", "path": "/x"}]`,
+			},
+			want: want{
+				err: ErrInvalidPatch,
+			},
+		},
+		{
+			name: "Not A Patch",
+			args: args{
+				patch: `{"op": "add"}`,
+			},
+			want: want{
+				err: ErrInvalidPatch,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := doc.ApplyPatch([]byte(tc.args.patch))
+			assert.True(t, errors.Is(err, tc.want.err), "got error %v", err)
+			assert.Equal(t, tc.want.output, output)
+		})
+	}
+
+	// A failing patch leaves the document untouched.
+	_, err := doc.ApplyPatch([]byte(`[{"op": "remove", "path": "/name"}, {"op": "remove", "path": "/nothing"}]`))
+	var perr *PatchError
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, 1, perr.Index)
+	assert.Equal(t, "Deepak", doc["name"])
+}
+
+func TestPropApplyMergePatch(t *testing.T) {
+	doc := Prop{
+		"name": "Deepak",
+		"shop": map[string]interface{}{"plan": "basic", "id": 7.0},
+		"tags": []interface{}{"a"},
+	}
+
+	output, err := doc.ApplyMergePatch([]byte(`{"name": null, "shop": {"plan": "pro", "id": null}, "tags": ["b"], "page": "fb"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, Prop{
+		"shop": map[string]interface{}{"plan": "pro"},
+		"tags": []interface{}{"b"},
+		"page": "fb",
+	}, output)
+	assert.Equal(t, "Deepak", doc["name"])
+
+	_, err = doc.ApplyMergePatch([]byte(`["not", "an", "object"]`))
+	assert.True(t, errors.Is(err, ErrInvalidPatch))
+}
@@ -0,0 +1,205 @@
+package utility
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testProp() Prop {
+	p := Prop{}
+	_ = json.Unmarshal([]byte(`{
+		"customer": {
+			"name": "Deepak",
+			"age": "31",
+			"vip": true,
+			"phones": [{"number": "19700000987", "primary": true}, {"number": "19700001234"}]
+		},
+		"total": 12.5,
+		"tags": ["a", "b", "c"]
+	}`), &p)
+	return p
+}
+
+func TestPropGet(t *testing.T) {
+	type args struct {
+		path string
+	}
+	type want struct {
+		output interface{}
+		err    error
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Nested Key",
+			args: args{
+				path: "customer.name",
+			},
+			want: want{
+				output: "Deepak",
+			},
+		},
+		{
+			name: "Indexed Path",
+			args: args{
+				path: "customer.phones[1].number",
+			},
+			want: want{
+				output: "19700001234",
+			},
+		},
+		{
+			name: "Index Into Array",
+			args: args{
+				path: "tags[2]",
+			},
+			want: want{
+				output: "c",
+			},
+		},
+		{
+			name: "Missing Key",
+			args: args{
+				path: "customer.email",
+			},
+			want: want{
+				err: ErrPathNotFound,
+			},
+		},
+		{
+			name: "Index Out Of Range",
+			args: args{
+				path: "customer.phones[5].number",
+			},
+			want: want{
+				err: ErrPathNotFound,
+			},
+		},
+		{
+			name: "Key Into String",
+			args: args{
+				path: "customer.name.first",
+			},
+			want: want{
+				err: ErrPathType,
+			},
+		},
+		{
+			name: "Bad Index",
+			args: args{
+				path: "tags[x]",
+			},
+			want: want{
+				err: ErrInvalidPath,
+			},
+		},
+		{
+			name: "Empty Segment",
+			args: args{
+				path: "customer..name",
+			},
+			want: want{
+				err: ErrInvalidPath,
+			},
+		},
+	}
+
+	p := testProp()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := p.Get(tc.args.path)
+			assert.True(t, errors.Is(err, tc.want.err), "got error %v", err)
+			assert.Equal(t, tc.want.output, output)
+		})
+	}
+}
+
+func TestPropTypedGetters(t *testing.T) {
+	p := testProp()
+
+	s, err := p.GetString("customer.phones[0].number")
+	assert.NoError(t, err)
+	assert.Equal(t, "19700000987", s)
+
+	age, err := p.GetInt("customer.age")
+	assert.NoError(t, err)
+	assert.Equal(t, 31, age)
+
+	_, err = p.GetInt("total")
+	assert.True(t, errors.Is(err, ErrTruncated))
+
+	f, err := p.GetFloat64("total")
+	assert.NoError(t, err)
+	assert.Equal(t, 12.5, f)
+
+	vip, err := p.GetBool("customer.vip")
+	assert.NoError(t, err)
+	assert.True(t, vip)
+
+	customer, err := p.GetMap("customer")
+	assert.NoError(t, err)
+	assert.Equal(t, "Deepak", customer["name"])
+
+	_, err = p.GetMap("customer.name")
+	assert.True(t, errors.Is(err, ErrWrongType))
+
+	phones, err := p.GetSlice("customer.phones")
+	assert.NoError(t, err)
+	assert.Len(t, phones, 2)
+
+	assert.Equal(t, "none", PropGetOr(p, "customer.email", "none"))
+	assert.Equal(t, int64(31), PropGetOr(p, "customer.age", int64(0)))
+	assert.True(t, p.Has("customer.phones[0].primary"))
+	assert.False(t, p.Has("customer.phones[1].primary"))
+}
+
+func TestPropSet(t *testing.T) {
+	p := testProp()
+
+	assert.NoError(t, p.Set("customer.address.city", "Denver"))
+	city, _ := p.GetString("customer.address.city")
+	assert.Equal(t, "Denver", city)
+
+	assert.NoError(t, p.Set("customer.phones[0].number", "19700005555"))
+	number, _ := p.GetString("customer.phones[0].number")
+	assert.Equal(t, "19700005555", number)
+
+	assert.NoError(t, p.Set("customer.phones[2].number", "19700006666"))
+	phones, _ := p.GetSlice("customer.phones")
+	assert.Len(t, phones, 3)
+
+	assert.NoError(t, p.Set("notes[1]", "second"))
+	assert.Equal(t, []interface{}{nil, "second"}, p["notes"])
+
+	err := p.Set("customer.name.first", "D")
+	assert.True(t, errors.Is(err, ErrPathType))
+	assert.Equal(t, "Deepak", PropGetOr(p, "customer.name", ""))
+
+	var nilProp Prop
+	assert.True(t, errors.Is(nilProp.Set("a", 1), ErrPathType))
+}
+
+func TestPropDelete(t *testing.T) {
+	p := testProp()
+
+	assert.True(t, p.Delete("customer.phones[0]"))
+	number, _ := p.GetString("customer.phones[0].number")
+	assert.Equal(t, "19700001234", number)
+
+	assert.True(t, p.Delete("customer.vip"))
+	assert.False(t, p.Has("customer.vip"))
+
+	assert.False(t, p.Delete("customer.vip"))
+	assert.False(t, p.Delete("tags[3]"))
+	assert.False(t, p.Delete("tags.x"))
+
+	assert.True(t, p.Delete("tags[1]"))
+	assert.Equal(t, []interface{}{"a", "c"}, p["tags"])
+}
@@ -0,0 +1,136 @@
+package utility
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// RequestIDHeader is the request header Recover takes the request ID from.
+const RequestIDHeader = "X-Request-ID"
+
+// requestScope records the request ID and target set on any context derived
+// from the one Recover passes down, so they can be logged after a panic.
+type requestScope struct {
+	mu        sync.Mutex
+	requestID string
+	target    string
+}
+
+type scopeKey struct{}
+
+func scopeFrom(ctx context.Context) *requestScope {
+	scope, _ := ctx.Value(scopeKey{}).(*requestScope)
+	return scope
+}
+
+// Recover is a middleware that recovers from panics in next using the default
+// Service, see Service.Recover.
+func Recover(next http.Handler) http.Handler {
+	return Default().Recover(next)
+}
+
+// Recover is a middleware that recovers from panics in next, such as those
+// raised by Panic. The error is logged with its stack trace, the request ID
+// and the target of the request, unless Panic logged it already, and a 500 is
+// rendered like RenderErrorFor does unless next already started the response. The request ID is taken from
+// the X-Request-ID header, or generated, and added to the request context with
+// WithRequestID. http.ErrAbortHandler is panicked again so net/http aborts the
+// response as usual.
+func (s *Service) Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if RequestIDFrom(ctx) == "" {
+			id := r.Header.Get(RequestIDHeader)
+			if id == "" {
+				id = UUID()
+			}
+			ctx = WithRequestID(ctx, id)
+		}
+		scope := &requestScope{requestID: RequestIDFrom(ctx), target: TargetFrom(ctx)}
+		ctx = context.WithValue(ctx, scopeKey{}, scope)
+		tw := &trackingWriter{ResponseWriter: w}
+
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+			err, ok := rec.(error)
+			if !ok {
+				err = fmt.Errorf("panic: %v", rec)
+			}
+			err = withStack(err, 2)
+
+			scope.mu.Lock()
+			requestID, target := scope.requestID, scope.target
+			scope.mu.Unlock()
+			logCtx := WithRequestID(ctx, requestID)
+			if target != "" {
+				logCtx = WithTarget(logCtx, target)
+			}
+			// Errors raised by Panic were logged when they were raised.
+			var se *StackError
+			if !errors.As(err, &se) || !se.logged {
+				logPanic(s.LogFrom(logCtx), err.Error(),
+					"method", r.Method,
+					"path", r.URL.Path,
+					"stack", Stack(err),
+				)
+			}
+
+			// Once the status line is sent an error body would only corrupt the
+			// response, so it is left as written.
+			if !tw.wrote {
+				s.writeError(w, r.WithContext(logCtx), Internal(err))
+			}
+		}()
+		next.ServeHTTP(tw, r.WithContext(ctx))
+	})
+}
+
+// trackingWriter records whether the handler started the response.
+type trackingWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (w *trackingWriter) WriteHeader(status int) {
+	w.wrote = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *trackingWriter) Write(b []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher if the underlying ResponseWriter does.
+func (w *trackingWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wrote = true
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker if the underlying ResponseWriter does.
+func (w *trackingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("utility: %T does not support hijacking", w.ResponseWriter)
+	}
+	w.wrote = true
+	return h.Hijack()
+}
+
+// Unwrap returns the underlying ResponseWriter, for http.ResponseController.
+func (w *trackingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,122 @@
+package utility
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+)
+
+func TestRecover(t *testing.T) {
+	rec := NewRecordLogger()
+	s := &Service{Logger: rec, R: render.New()}
+
+	h := s.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := WithTarget(r.Context(), "fb:page1:user1")
+		s.PrintCtx(ctx, "sending")
+		panic(errors.New("upstream down"))
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"error":{"code":"internal","message":"Internal Server Error","retryable":false,"request_id":"req-42"}}`, w.Body.String())
+
+	records := rec.Records()
+	assert.Len(t, records, 2)
+	assert.Equal(t, "info", records[0].Level)
+	logged := records[1]
+	assert.Equal(t, "error", logged.Level)
+	assert.Equal(t, "upstream down", logged.Msg)
+	assert.Equal(t, "req-42", logged.Fields[FieldRequestID])
+	assert.Equal(t, "fb:page1:user1", logged.Fields[FieldTarget])
+	assert.Equal(t, "page1", logged.Fields[FieldAccountID])
+	assert.Equal(t, "/webhook", logged.Fields["path"])
+	assert.Contains(t, logged.Fields["stack"], "utility.TestRecover")
+}
+
+func TestRecoverPanic(t *testing.T) {
+	rec := NewRecordLogger()
+	s := &Service{Logger: rec, R: render.New()}
+
+	h := s.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		func() {
+			defer func() { recover() }()
+			s.Panic(errors.New("handled"))
+		}()
+		s.Panic(errors.New("upstream down"))
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	records := rec.Records()
+	assert.Len(t, records, 2)
+	assert.Equal(t, "handled", records[0].Msg)
+	assert.Equal(t, "upstream down", records[1].Msg)
+	assert.Equal(t, "error", records[1].Level)
+}
+
+func TestRecoverValues(t *testing.T) {
+	rec := NewRecordLogger()
+	s := &Service{Logger: rec, R: render.New()}
+
+	h := s.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, RequestIDFrom(r.Context()))
+		var m map[string]int
+		m["boom"]++
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, rec.Records()[0].Msg, "assignment to entry in nil map")
+	assert.NotEmpty(t, rec.Records()[0].Fields[FieldRequestID])
+
+	h = s.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("plain string")
+	}))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "panic: plain string", rec.Records()[1].Msg)
+
+	h = s.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+
+	h = s.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestRecoverAfterWrite(t *testing.T) {
+	log, buf := testLogger()
+	s := NewService(log, render.New(), Options{})
+
+	h := s.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		s.Panic(errors.New("stream broke"))
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/export", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "partial", w.Body.String())
+	assert.True(t, w.Flushed)
+	assert.Equal(t, 1, strings.Count(buf.String(), "stream broke"))
+	assert.Contains(t, buf.String(), "level=panic")
+}
@@ -0,0 +1,287 @@
+package utility
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/unrolled/render"
+)
+
+// Options configures a Service.
+type Options struct {
+	// PhoneRegion is the region of numbers written without a country code,
+	// DefaultPhoneRegion if empty.
+	PhoneRegion string
+	// JSON are the options used by Marshal, Unmarshal, JSON2Map, JSON2Slice
+	// and DecodeBody, before any passed to the call.
+	JSON []JSONOption
+	// BodyLimit caps the bytes DecodeBody reads, 0 means no limit.
+	BodyLimit int64
+	// ProblemJSON makes RenderError write RFC 7807 application/problem+json
+	// instead of the JSON error envelope.
+	ProblemJSON bool
+}
+
+// Service holds the logger, renderer and options used by the helpers of this
+// package. Its methods mirror the package functions, which use the default
+// Service. Messages go to Logger, or to Log if Logger is nil. Without either,
+// or without R, it falls back to a logrus logger writing to stderr and a
+// renderer with the default options.
+type Service struct {
+	Log     *logrus.Entry
+	Logger  Logger
+	R       *render.Render
+	Options Options
+}
+
+// NewService returns a Service using log and r, either of which may be nil.
+func NewService(log *logrus.Entry, r *render.Render, opts Options) *Service {
+	s := &Service{Log: log, R: r, Options: opts}
+	s.Log = s.logrusEntry()
+	s.R = s.renderer()
+	return s
+}
+
+var (
+	stdMu sync.RWMutex
+	std   = &Service{}
+
+	fallbackLogOnce    sync.Once
+	fallbackLog        *logrus.Entry
+	fallbackRenderOnce sync.Once
+	fallbackRender     *render.Render
+)
+
+// Default returns the Service used by the package functions. If Log or R were
+// assigned directly instead of through SetupService or SetDefault, it returns a
+// copy of that Service using them, so such assignments keep working.
+func Default() *Service {
+	stdMu.RLock()
+	s, log, r := std, Log, R
+	stdMu.RUnlock()
+
+	logSet := log != nil && log != s.logrusEntry()
+	rSet := r != nil && r != s.R
+	if !logSet && !rSet {
+		return s
+	}
+	c := *s
+	if logSet {
+		c.Log, c.Logger = log, nil
+	}
+	if rSet {
+		c.R = r
+	}
+	return &c
+}
+
+// SetDefault makes s the Service used by the package functions, and sets Log
+// and R to its logrus logger and renderer.
+func SetDefault(s *Service) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	std = s
+	Log = s.logrusEntry()
+	R = s.R
+}
+
+// logger returns the Logger messages go to.
+func (s *Service) logger() Logger {
+	if s != nil && s.Logger != nil {
+		return s.Logger
+	}
+	return LogrusLogger(s.logrusEntry())
+}
+
+// logrusEntry returns s.Log or the stderr fallback.
+func (s *Service) logrusEntry() *logrus.Entry {
+	if s != nil && s.Log != nil {
+		return s.Log
+	}
+	fallbackLogOnce.Do(func() {
+		fallbackLog = logrus.NewEntry(logrus.New())
+	})
+	return fallbackLog
+}
+
+// renderer returns s.R or a renderer with the default options. The fallback is
+// created on first use, as render.New looks for templates on disk.
+func (s *Service) renderer() *render.Render {
+	if s != nil && s.R != nil {
+		return s.R
+	}
+	fallbackRenderOnce.Do(func() {
+		fallbackRender = render.New()
+	})
+	return fallbackRender
+}
+
+// jsonOptions returns the Service's JSON options followed by opts.
+func (s *Service) jsonOptions(opts []JSONOption) []JSONOption {
+	if s == nil || len(s.Options.JSON) == 0 {
+		return opts
+	}
+	return append(s.Options.JSON[:len(s.Options.JSON):len(s.Options.JSON)], opts...)
+}
+
+// phoneRegion returns the region of numbers without a country code.
+func (s *Service) phoneRegion() string {
+	if s == nil || s.Options.PhoneRegion == "" {
+		return DefaultPhoneRegion
+	}
+	return s.Options.PhoneRegion
+}
+
+// ReadAll read and send the body in []byte form...
+func (s *Service) ReadAll(body io.ReadCloser) []byte {
+	b, e := ioutil.ReadAll(body)
+	s.PrintError(e)
+	return b
+}
+
+// Panic check and panic if needed... e is logged at logrus' panic level
+// first, or as an error by other Loggers, and panicked annotated with the stack
+// trace, see WithStack. Recover turns the panic into a 500 response without
+// logging e again.
+func (s *Service) Panic(e error) {
+	if e != nil {
+		err := withStack(e, 1)
+		logPanic(s.logger(), err.Error())
+		panic(&StackError{Err: err, logged: true})
+	}
+}
+
+// PrintError ... e is logged as info, like logrus' Println. Use PrintErrorCtx
+// to log at error level.
+func (s *Service) PrintError(e error) {
+	if e != nil {
+		s.logger().Info(e.Error())
+	}
+}
+
+// Print ... str is logged as info.
+func (s *Service) Print(str interface{}) {
+	s.logger().Info(fmt.Sprint(str))
+}
+
+// Unmarshal ... Use UnmarshalE to get the error.
+func (s *Service) Unmarshal(body []byte, v interface{}) interface{} {
+	s.PrintError(UnmarshalE(body, v, s.jsonOptions(nil)...))
+	return v
+}
+
+// Marshal ... Use MarshalE to get the error.
+func (s *Service) Marshal(v interface{}) []byte {
+	data, err := MarshalE(v, s.jsonOptions(nil)...)
+	s.PrintError(err)
+	return data
+}
+
+// DecodeBody decodes the JSON body into v with the Service's JSON options and
+// BodyLimit, and closes body.
+func (s *Service) DecodeBody(body io.ReadCloser, v interface{}) error {
+	var limit int64
+	if s != nil {
+		limit = s.Options.BodyLimit
+	}
+	return DecodeBody(body, v, limit, s.jsonOptions(nil)...)
+}
+
+// ParseForm ..
+func (s *Service) ParseForm(r *http.Request) {
+	s.PrintError(r.ParseForm())
+}
+
+// Int64 ... Use ParseInt64 to get the error.
+func (s *Service) Int64(v string) int64 {
+	if IsBlank(v) {
+		return 0
+	}
+	intV, err := ParseInt64(v)
+	s.PrintError(err)
+	return intV
+}
+
+// Int ... Use ParseInt to get the error.
+func (s *Service) Int(v string) int {
+	if IsBlank(v) {
+		return 0
+	}
+	intV, err := ParseInt(v)
+	s.PrintError(err)
+	return intV
+}
+
+// Float642Int ... Use FloatAsInt to get the error.
+func (s *Service) Float642Int(v interface{}) int {
+	if IsBlank(v) {
+		return 0
+	}
+	intV, err := FloatAsInt(v)
+	s.PrintError(err)
+	return intV
+}
+
+// Float64 ... Use AsFloat64 to get the error.
+func (s *Service) Float64(v interface{}) float64 {
+	if IsBlank(v) {
+		return 0
+	}
+	f, err := AsFloat64(v)
+	s.PrintError(err)
+	return f
+}
+
+// ToInt ... Use AsInt to get the error.
+func (s *Service) ToInt(v interface{}) int {
+	if IsBlank(v) {
+		return 0
+	}
+	intV, err := AsInt(v)
+	s.PrintError(err)
+	return intV
+}
+
+// FormatPhone formats v for display in the Service's PhoneRegion, see the
+// FormatPhone function.
+func (s *Service) FormatPhone(v string) string {
+	formatted, err := FormatPhoneAs(v, s.phoneRegion(), International)
+	if err != nil {
+		return v
+	}
+	return formatted
+}
+
+// ConvertMap ... Use AsMap to get the error.
+func (s *Service) ConvertMap(v interface{}) map[string]interface{} {
+	if IsBlank(v) {
+		return nil
+	}
+	mapp, err := AsMap(v)
+	s.PrintError(err)
+	return mapp
+}
+
+// JSON2Map ... Use ParseJSONMap to get the error.
+func (s *Service) JSON2Map(v interface{}, opts ...JSONOption) map[string]interface{} {
+	if IsBlank(v) {
+		return nil
+	}
+	mapp, err := ParseJSONMap(v, s.jsonOptions(opts)...)
+	s.PrintError(err)
+	return mapp
+}
+
+// JSON2Slice ... Use ParseJSONSlice to get the error.
+func (s *Service) JSON2Slice(v interface{}, opts ...JSONOption) []interface{} {
+	if IsBlank(v) {
+		return nil
+	}
+	slice, err := ParseJSONSlice(v, s.jsonOptions(opts)...)
+	s.PrintError(err)
+	return slice
+}
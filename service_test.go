@@ -0,0 +1,104 @@
+package utility
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+)
+
+func testLogger() (*logrus.Entry, *bytes.Buffer) {
+	var buf bytes.Buffer
+	l := logrus.New()
+	l.SetOutput(&buf)
+	l.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true})
+	return logrus.NewEntry(l), &buf
+}
+
+func TestServiceZeroValue(t *testing.T) {
+	var s Service
+	assert.NotPanics(t, func() {
+		s.PrintError(nil)
+		assert.Equal(t, 0, s.Int(""))
+	})
+	assert.NotNil(t, s.logger())
+	assert.NotNil(t, s.renderer())
+
+	s2 := NewService(nil, nil, Options{})
+	assert.NotNil(t, s2.Log)
+	assert.NotNil(t, s2.R)
+}
+
+func TestServiceLogs(t *testing.T) {
+	log, buf := testLogger()
+	s := NewService(log, nil, Options{})
+
+	assert.Equal(t, 0, s.Int("xyz"))
+	assert.Contains(t, buf.String(), "level=info")
+	assert.Contains(t, buf.String(), "invalid syntax")
+
+	buf.Reset()
+	s.PrintError(nil)
+	assert.Equal(t, "", buf.String())
+
+	assert.Panics(t, func() {
+		s.Panic(errors.New("boom"))
+	})
+	assert.Contains(t, buf.String(), "level=panic")
+	assert.Contains(t, buf.String(), "boom")
+}
+
+func TestServiceOptions(t *testing.T) {
+	log, buf := testLogger()
+	s := NewService(log, render.New(), Options{
+		PhoneRegion: "GB",
+		JSON:        []JSONOption{UseNumber(), EscapeHTML(false)},
+		BodyLimit:   10,
+	})
+
+	assert.Equal(t, "+44 20 7946 0018", s.FormatPhone("020 7946 0018"))
+	assert.Equal(t, `{"a":"<b>"}`, string(s.Marshal(map[string]string{"a": "<b>"})))
+
+	m := s.JSON2Map(`{"id":12345678901234567890}`)
+	assert.NotNil(t, m)
+	assert.Equal(t, "12345678901234567890", ToString(m["id"]))
+
+	var v map[string]interface{}
+	err := s.DecodeBody(ioutil.NopCloser(strings.NewReader(`{"id":12345}`)), &v)
+	assert.True(t, errors.Is(err, ErrTooLarge))
+	assert.Equal(t, "", buf.String())
+}
+
+func TestSetDefault(t *testing.T) {
+	prev := Default()
+	defer SetDefault(prev)
+
+	log, buf := testLogger()
+	SetupService(log, nil)
+	assert.Equal(t, log, Log)
+	assert.NotNil(t, R)
+	assert.Equal(t, log, Default().Log)
+
+	PrintError(errors.New("through the default"))
+	assert.Contains(t, buf.String(), "through the default")
+}
+
+func TestAssignLog(t *testing.T) {
+	prev := Default()
+	defer SetDefault(prev)
+
+	SetDefault(&Service{Logger: NewRecordLogger()})
+	log, buf := testLogger()
+	r := render.New()
+	Log, R = log, r
+
+	assert.Equal(t, log, Default().Log)
+	assert.Equal(t, r, Default().R)
+	PrintError(errors.New("through Log"))
+	assert.Contains(t, buf.String(), "through Log")
+}
@@ -0,0 +1,127 @@
+package utility
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// ErrNotArray is returned by ArrayIterator when the input is not a JSON array.
+var ErrNotArray = errors.New("JSON input is not an array")
+
+// DecodeBody decodes the JSON value in body into v without reading the whole
+// body into memory first, and closes body. A limit above 0 caps the number of
+// bytes read, larger bodies fail with ErrTooLarge. Errors are *JSONError
+// values, as returned by UnmarshalE.
+func DecodeBody(body io.ReadCloser, v interface{}, limit int64, opts ...JSONOption) error {
+	defer body.Close()
+	err := decodeJSON(bodyOptions(limit, opts).decoder(body), v)
+	if je, ok := err.(*JSONError); ok {
+		je.body = true
+	}
+	return err
+}
+
+// bodyOptions returns the options with limit applied on top of any MaxSize.
+func bodyOptions(limit int64, opts []JSONOption) jsonOptions {
+	o := newJSONOptions(opts)
+	if limit > 0 && (o.maxSize == 0 || limit < o.maxSize) {
+		o.maxSize = limit
+	}
+	return o
+}
+
+// ArrayIterator decodes the elements of a top-level JSON array one at a time.
+//
+//	it := NewArrayIterator(r.Body, 50<<20)
+//	defer it.Close()
+//	var c Contact
+//	for it.Next(&c) {
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ArrayIterator struct {
+	body    io.ReadCloser
+	dec     *json.Decoder
+	index   int
+	started bool
+	done    bool
+	closed  bool
+	err     error
+}
+
+// NewArrayIterator returns an iterator over the JSON array in body. A limit
+// above 0 caps the number of bytes read from body.
+func NewArrayIterator(body io.ReadCloser, limit int64, opts ...JSONOption) *ArrayIterator {
+	return &ArrayIterator{
+		body: body,
+		dec:  bodyOptions(limit, opts).decoder(body),
+	}
+}
+
+// Next decodes the next element into v, which is zeroed first so fields of
+// an earlier element don't carry over. It returns false at the end of the
+// array or on an error, and closes the body then.
+func (it *ArrayIterator) Next(v interface{}) bool {
+	if it.done {
+		return false
+	}
+	if !it.started {
+		it.started = true
+		tok, err := it.dec.Token()
+		if err != nil {
+			return it.fail(err)
+		}
+		if tok != json.Delim('[') {
+			return it.fail(ErrNotArray)
+		}
+	}
+	if !it.dec.More() {
+		if _, err := it.dec.Token(); err != nil {
+			return it.fail(err)
+		}
+		if err := expectEOF(it.dec); err != nil {
+			return it.fail(err)
+		}
+		it.done = true
+		it.Close()
+		return false
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+	}
+	if err := it.dec.Decode(v); err != nil {
+		return it.fail(fmt.Errorf("element %d: %w", it.index, err))
+	}
+	it.index++
+	return true
+}
+
+func (it *ArrayIterator) fail(err error) bool {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	it.err = &JSONError{Op: "unmarshal", Err: err}
+	it.done = true
+	it.Close()
+	return false
+}
+
+// Err returns the error that stopped the iteration, nil at the end of the array.
+func (it *ArrayIterator) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and closes the body. It is safe to call more than once.
+func (it *ArrayIterator) Close() error {
+	it.done = true
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+	return it.body.Close()
+}
@@ -0,0 +1,169 @@
+package utility
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testBody struct {
+	io.Reader
+	closed int
+}
+
+func (b *testBody) Close() error {
+	b.closed++
+	return nil
+}
+
+func newTestBody(s string) *testBody {
+	return &testBody{Reader: strings.NewReader(s)}
+}
+
+func TestDecodeBody(t *testing.T) {
+	type args struct {
+		body  string
+		limit int64
+		opts  []JSONOption
+	}
+	type want struct {
+		output map[string]interface{}
+		err    error
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Valid",
+			args: args{
+				body: `{"target":"fb:1:2"}`,
+			},
+			want: want{
+				output: map[string]interface{}{"target": "fb:1:2"},
+			},
+		},
+		{
+			name: "Within Limit",
+			args: args{
+				body:  `{"target":"fb:1:2"}`,
+				limit: 19,
+			},
+			want: want{
+				output: map[string]interface{}{"target": "fb:1:2"},
+			},
+		},
+		{
+			name: "Over Limit",
+			args: args{
+				body:  `{"target":"fb:1:2"}`,
+				limit: 18,
+			},
+			want: want{
+				err: ErrTooLarge,
+			},
+		},
+		{
+			name: "Too Deep",
+			args: args{
+				body: `{"a":{"b":{}}}`,
+				opts: []JSONOption{MaxDepth(2)},
+			},
+			want: want{
+				err: ErrTooDeep,
+			},
+		},
+		{
+			name: "Trailing Data",
+			args: args{
+				body: `{} []`,
+			},
+			want: want{
+				output: map[string]interface{}{},
+				err:    ErrTrailingData,
+			},
+		},
+		{
+			name: "Empty",
+			args: args{
+				body: ``,
+			},
+			want: want{
+				err: io.ErrUnexpectedEOF,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := newTestBody(tc.args.body)
+			var output map[string]interface{}
+			err := DecodeBody(body, &output, tc.args.limit, tc.args.opts...)
+			assert.True(t, errors.Is(err, tc.want.err), "got error %v", err)
+			assert.Equal(t, tc.want.output, output)
+			assert.Equal(t, 1, body.closed)
+		})
+	}
+}
+
+func TestArrayIterator(t *testing.T) {
+	type contact struct {
+		Phone string `json:"phone"`
+	}
+
+	body := newTestBody(` [{"phone":"19700000987"}, {"phone":"19700001234"}] `)
+	it := NewArrayIterator(body, 0)
+	var phones []string
+	var c contact
+	for it.Next(&c) {
+		phones = append(phones, c.Phone)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"19700000987", "19700001234"}, phones)
+	assert.Equal(t, 1, body.closed)
+	assert.NoError(t, it.Close())
+	assert.Equal(t, 1, body.closed)
+
+	it = NewArrayIterator(newTestBody(`[]`), 0)
+	assert.False(t, it.Next(&c))
+	assert.NoError(t, it.Err())
+
+	it = NewArrayIterator(newTestBody(`{"phone":"1"}`), 0)
+	assert.False(t, it.Next(&c))
+	assert.True(t, errors.Is(it.Err(), ErrNotArray))
+
+	it = NewArrayIterator(newTestBody(`[{"phone":"1"}, {"phone":2}]`), 0)
+	assert.True(t, it.Next(&c))
+	assert.False(t, it.Next(&c))
+	assert.Contains(t, it.Err().Error(), "element 1")
+
+	it = NewArrayIterator(newTestBody(`[{"phone":"1"}, {"phone":"2"}]`), 20)
+	assert.True(t, it.Next(&c))
+	assert.False(t, it.Next(&c))
+	assert.True(t, errors.Is(it.Err(), ErrTooLarge))
+
+	it = NewArrayIterator(newTestBody(`[{"phone":"1"}`), 0)
+	assert.True(t, it.Next(&c))
+	assert.False(t, it.Next(&c))
+	assert.Error(t, it.Err())
+}
+
+func TestArrayIteratorResetsValue(t *testing.T) {
+	type pair struct {
+		A, B string
+	}
+
+	it := NewArrayIterator(newTestBody(`[{"A":"x","B":"y"},{"A":"z"}]`), 0)
+	var got []pair
+	var p pair
+	for it.Next(&p) {
+		got = append(got, p)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []pair{{A: "x", B: "y"}, {A: "z"}}, got)
+}
@@ -0,0 +1,104 @@
+package utility
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TargetSeparator separates the components of a Target.
+const TargetSeparator = ":"
+
+var (
+	// ErrEmptyTarget is returned when parsing a blank target.
+	ErrEmptyTarget = errors.New("utility: empty target")
+	// ErrMalformedTarget is returned when a target has an empty, padded or
+	// missing component, or is an SMS target that is not a phone number.
+	ErrMalformedTarget = errors.New("utility: malformed target")
+)
+
+// TargetParts is the structured form of a Target.
+//
+// A channel target looks like "<channel>:<account>:<conversation>[:<rest>]",
+// e.g. "fb:<page id>:<user id>". A target without a registered channel
+// prefix is an SMS target, a phone number or short code written as digits
+// with an optional leading "+", and is kept whole in Conversation.
+type TargetParts struct {
+	// Channel is the registered channel prefix, empty for SMS.
+	Channel string
+	// Account is the page, business or account ID on the channel.
+	Account string
+	// Conversation is the thread or user ID, or the phone number for SMS.
+	Conversation string
+	// Rest is the raw remainder after the conversation component.
+	Rest string
+}
+
+// ParseTarget splits target into its components. Channel targets need the
+// account and conversation components, and every component must be non-empty
+// and must not have surrounding whitespace.
+func ParseTarget(target string) (TargetParts, error) {
+	if strings.TrimSpace(target) == "" {
+		return TargetParts{}, ErrEmptyTarget
+	}
+	if strings.TrimSpace(target) != target {
+		return TargetParts{}, fmt.Errorf("%w: %q has surrounding whitespace", ErrMalformedTarget, target)
+	}
+
+	segments := strings.SplitN(target, TargetSeparator, 4)
+	if _, ok := LookupChannel(segments[0]); !ok {
+		if !isDigits(strings.TrimPrefix(target, "+")) {
+			return TargetParts{}, fmt.Errorf("%w: %q is neither a channel target nor a phone number", ErrMalformedTarget, target)
+		}
+		return TargetParts{Conversation: target}, nil
+	}
+	if len(segments) < 3 {
+		return TargetParts{}, fmt.Errorf("%w: %q needs an account and a conversation", ErrMalformedTarget, target)
+	}
+	for i, seg := range segments {
+		if seg == "" || strings.TrimSpace(seg) != seg {
+			return TargetParts{}, fmt.Errorf("%w: %q has an invalid component at position %d", ErrMalformedTarget, target, i)
+		}
+	}
+
+	parts := TargetParts{Channel: segments[0], Account: segments[1], Conversation: segments[2]}
+	if len(segments) > 3 {
+		parts.Rest = segments[3]
+	}
+	return parts, nil
+}
+
+// Parse is ParseTarget for a Target value.
+func (t Target) Parse() (TargetParts, error) {
+	return ParseTarget(string(t))
+}
+
+// Channel returns the Channel the target is routed through.
+func (t Target) Channel() Channel {
+	return ChannelFor(string(t))
+}
+
+// String joins the components back into the target they were parsed from.
+func (p TargetParts) String() string {
+	if p.Channel == "" {
+		return p.Conversation
+	}
+	segments := []string{p.Channel}
+	for _, seg := range []string{p.Account, p.Conversation, p.Rest} {
+		if seg == "" {
+			break
+		}
+		segments = append(segments, seg)
+	}
+	return strings.Join(segments, TargetSeparator)
+}
+
+// Target returns the components as a Target.
+func (p TargetParts) Target() Target {
+	return Target(p.String())
+}
+
+// IsSMS reports whether the target is routed through SMS.
+func (p TargetParts) IsSMS() bool {
+	return p.Channel == ""
+}
@@ -0,0 +1,143 @@
+package utility
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTarget(t *testing.T) {
+	type args struct {
+		target string
+	}
+	type want struct {
+		parts TargetParts
+		err   error
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Facebook Page And User",
+			args: args{
+				target: "fb:1001:2002",
+			},
+			want: want{
+				parts: TargetParts{Channel: "fb", Account: "1001", Conversation: "2002"},
+			},
+		},
+		{
+			name: "Remainder Is Kept Raw",
+			args: args{
+				target: "gmb:agent:conv:extra:more",
+			},
+			want: want{
+				parts: TargetParts{Channel: "gmb", Account: "agent", Conversation: "conv", Rest: "extra:more"},
+			},
+		},
+		{
+			name: "SMS Phone Number",
+			args: args{
+				target: "19700000987",
+			},
+			want: want{
+				parts: TargetParts{Conversation: "19700000987"},
+			},
+		},
+		{
+			name: "SMS E164 Number",
+			args: args{
+				target: "+19700000987",
+			},
+			want: want{
+				parts: TargetParts{Conversation: "+19700000987"},
+			},
+		},
+		{
+			name: "Channel And Account Only",
+			args: args{
+				target: "line:channel",
+			},
+			want: want{
+				err: ErrMalformedTarget,
+			},
+		},
+		{
+			name: "Channel Only",
+			args: args{
+				target: "fb",
+			},
+			want: want{
+				err: ErrMalformedTarget,
+			},
+		},
+		{
+			name: "SMS Not A Phone Number",
+			args: args{
+				target: "hello world",
+			},
+			want: want{
+				err: ErrMalformedTarget,
+			},
+		},
+		{
+			name: "Blank",
+			args: args{
+				target: " ",
+			},
+			want: want{
+				err: ErrEmptyTarget,
+			},
+		},
+		{
+			name: "Empty Component",
+			args: args{
+				target: "fb::2002",
+			},
+			want: want{
+				err: ErrMalformedTarget,
+			},
+		},
+		{
+			name: "Trailing Separator",
+			args: args{
+				target: "abc:business:",
+			},
+			want: want{
+				err: ErrMalformedTarget,
+			},
+		},
+		{
+			name: "Padded Component",
+			args: args{
+				target: "fb: 1001:2002",
+			},
+			want: want{
+				err: ErrMalformedTarget,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parts, err := ParseTarget(tc.args.target)
+			assert.True(t, errors.Is(err, tc.want.err), "got error %v", err)
+			assert.Equal(t, tc.want.parts, parts)
+			if err == nil {
+				assert.Equal(t, tc.args.target, parts.String())
+			}
+		})
+	}
+}
+
+func TestTargetParse(t *testing.T) {
+	parts, err := Target("whatsapp:acct:19700000987").Parse()
+	assert.NoError(t, err)
+	assert.False(t, parts.IsSMS())
+	assert.Equal(t, Target("whatsapp:acct:19700000987"), parts.Target())
+	assert.Equal(t, "WhatsApp", Target("whatsapp:acct:19700000987").Channel().Name)
+}
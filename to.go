@@ -0,0 +1,347 @@
+package utility
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeLayouts are the layouts tried, in order, when converting a string to time.Time.
+var TimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// To converts v to T. T may be any integer, float, string or bool type,
+// time.Time, time.Duration, or a slice or map of those (nested as deep as
+// needed). Numbers follow AsInt64 and AsFloat64, narrowing is checked for
+// overflow, and:
+//
+//   - strings are parsed for numbers, bools, durations (time.ParseDuration) and
+//     times (TimeLayouts); numbers become strings in their shortest form
+//   - numbers are Unix seconds for time.Time and nanoseconds for time.Duration
+//   - slices and arrays convert element by element, maps key and value wise
+//
+// nil is an ErrBlank error, a T that can't hold v an ErrWrongType error.
+func To[T any](v any) (T, error) {
+	var zero T
+	t := reflect.TypeOf(&zero).Elem()
+	if out, ok := v.(T); ok && t.Kind() != reflect.Interface {
+		return out, nil
+	}
+	rv, err := convertTo(v, t)
+	if err != nil {
+		return zero, convError("To["+t.String()+"]", v, err)
+	}
+	return rv.Interface().(T), nil
+}
+
+// MustTo is like To but panics if v can't be converted.
+func MustTo[T any](v any) T {
+	out, err := To[T](v)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// convertTo converts v to a value of type t, returning a bare Err* value on
+// failure, prefixed with the index or key for elements of slices and maps.
+func convertTo(v interface{}, t reflect.Type) (reflect.Value, error) {
+	if v == nil {
+		return reflect.Value{}, ErrBlank
+	}
+	if src := reflect.ValueOf(v); src.Type() == t {
+		return src, nil
+	}
+	out := reflect.New(t).Elem()
+
+	switch t {
+	case timeType:
+		tm, err := toTime(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.Set(reflect.ValueOf(tm))
+		return out, nil
+	case durationType:
+		d, err := toDuration(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.SetInt(int64(d))
+		return out, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		src := reflect.ValueOf(v)
+		if !src.Type().Implements(t) {
+			return reflect.Value{}, ErrWrongType
+		}
+		out.Set(src)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := toInt64(v, false)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if out.OverflowInt(n) {
+			return reflect.Value{}, ErrOverflow
+		}
+		out.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := toUint64(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if out.OverflowUint(n) {
+			return reflect.Value{}, ErrOverflow
+		}
+		out.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat64(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if out.OverflowFloat(f) {
+			return reflect.Value{}, ErrOverflow
+		}
+		out.SetFloat(f)
+	case reflect.String:
+		s, err := toString(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.SetString(s)
+	case reflect.Bool:
+		b, err := toBool(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.SetBool(b)
+	case reflect.Slice:
+		return convertSlice(v, t)
+	case reflect.Map:
+		return convertMap(v, t)
+	case reflect.Ptr:
+		elem, err := convertTo(v, t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.Set(reflect.New(t.Elem()))
+		out.Elem().Set(elem)
+	default:
+		return reflect.Value{}, ErrWrongType
+	}
+	return out, nil
+}
+
+func convertSlice(v interface{}, t reflect.Type) (reflect.Value, error) {
+	src, err := indirectValue(v)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if src.Kind() == reflect.String && t.Elem().Kind() == reflect.Uint8 {
+		return reflect.ValueOf([]byte(src.String())).Convert(t), nil
+	}
+	if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+		return reflect.Value{}, ErrWrongType
+	}
+	if src.Kind() == reflect.Slice && src.IsNil() {
+		return reflect.Zero(t), nil
+	}
+	out := reflect.MakeSlice(t, src.Len(), src.Len())
+	for i := 0; i < src.Len(); i++ {
+		elem, err := convertTo(src.Index(i).Interface(), t.Elem())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("[%d]: %w", i, err)
+		}
+		out.Index(i).Set(elem)
+	}
+	return out, nil
+}
+
+func convertMap(v interface{}, t reflect.Type) (reflect.Value, error) {
+	src, err := indirectValue(v)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if src.Kind() != reflect.Map {
+		return reflect.Value{}, ErrWrongType
+	}
+	if src.IsNil() {
+		return reflect.Zero(t), nil
+	}
+	out := reflect.MakeMapWithSize(t, src.Len())
+	iter := src.MapRange()
+	for iter.Next() {
+		key, err := convertTo(iter.Key().Interface(), t.Key())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("key %v: %w", iter.Key(), err)
+		}
+		elem, err := convertTo(iter.Value().Interface(), t.Elem())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("[%v]: %w", iter.Key(), err)
+		}
+		out.SetMapIndex(key, elem)
+	}
+	return out, nil
+}
+
+// toUint64 converts the numeric value v to a non-negative integer.
+func toUint64(v interface{}) (uint64, error) {
+	if rv, err := indirectValue(v); err == nil {
+		switch rv.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return rv.Uint(), nil
+		case reflect.String:
+			if n, err := strconv.ParseUint(strings.TrimSpace(rv.String()), 10, 64); err == nil {
+				return n, nil
+			}
+		}
+	}
+	n, err := toInt64(v, false)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, ErrOverflow
+	}
+	return uint64(n), nil
+}
+
+// toString converts a scalar value to its string form.
+func toString(v interface{}) (string, error) {
+	switch s := v.(type) {
+	case string:
+		return s, nil
+	case []byte:
+		return string(s), nil
+	case json.Number:
+		return string(s), nil
+	case time.Time:
+		return s.Format(time.RFC3339Nano), nil
+	case time.Duration:
+		return s.String(), nil
+	case fmt.Stringer:
+		return s.String(), nil
+	}
+	rv, err := indirectValue(v)
+	if err != nil {
+		return "", err
+	}
+	if rv.Type() == timeType || rv.Type() == durationType {
+		return toString(rv.Interface())
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	}
+	return "", ErrWrongType
+}
+
+// toBool converts bools, strconv.ParseBool strings and numbers (non-zero is true).
+func toBool(v interface{}) (bool, error) {
+	rv, err := indirectValue(v)
+	if err != nil {
+		return false, err
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.String:
+		s := strings.TrimSpace(rv.String())
+		if s == "" {
+			return false, ErrBlank
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return false, ErrSyntax
+		}
+		return b, nil
+	}
+	f, err := toFloat64(rv.Interface())
+	if err != nil {
+		return false, err
+	}
+	return f != 0, nil
+}
+
+// toTime converts times, TimeLayouts strings and Unix seconds.
+func toTime(v interface{}) (time.Time, error) {
+	rv, err := indirectValue(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if rv.Type() == timeType {
+		return rv.Interface().(time.Time), nil
+	}
+	if rv.Kind() == reflect.String {
+		s := strings.TrimSpace(rv.String())
+		if s == "" {
+			return time.Time{}, ErrBlank
+		}
+		for _, layout := range TimeLayouts {
+			if tm, err := time.Parse(layout, s); err == nil {
+				return tm, nil
+			}
+		}
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return time.Time{}, ErrSyntax
+		}
+	}
+	f, err := toFloat64(rv.Interface())
+	if err != nil {
+		return time.Time{}, err
+	}
+	if math.IsNaN(f) || math.Abs(f) > math.MaxInt64/float64(time.Second) {
+		return time.Time{}, ErrOverflow
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC(), nil
+}
+
+// toDuration converts durations, time.ParseDuration strings and nanoseconds.
+func toDuration(v interface{}) (time.Duration, error) {
+	rv, err := indirectValue(v)
+	if err != nil {
+		return 0, err
+	}
+	if rv.Kind() == reflect.String {
+		s := strings.TrimSpace(rv.String())
+		if s == "" {
+			return 0, ErrBlank
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, ErrSyntax
+		}
+		return d, nil
+	}
+	n, err := toInt64(rv.Interface(), false)
+	return time.Duration(n), err
+}
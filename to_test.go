@@ -0,0 +1,174 @@
+package utility
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToInt8(t *testing.T) {
+	type args struct {
+		v any
+	}
+	type want struct {
+		output int8
+		err    error
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Int",
+			args: args{
+				v: 100,
+			},
+			want: want{
+				output: 100,
+			},
+		},
+		{
+			name: "String",
+			args: args{
+				v: "-100",
+			},
+			want: want{
+				output: -100,
+			},
+		},
+		{
+			name: "Overflow",
+			args: args{
+				v: 200,
+			},
+			want: want{
+				err: ErrOverflow,
+			},
+		},
+		{
+			name: "Nil",
+			args: args{
+				v: nil,
+			},
+			want: want{
+				err: ErrBlank,
+			},
+		},
+		{
+			name: "Map",
+			args: args{
+				v: map[string]any{},
+			},
+			want: want{
+				err: ErrWrongType,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := To[int8](tc.args.v)
+			assert.True(t, errors.Is(err, tc.want.err), "got error %v", err)
+			assert.Equal(t, tc.want.output, output)
+		})
+	}
+}
+
+func TestToScalars(t *testing.T) {
+	u, err := To[uint16]("65535")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(65535), u)
+
+	_, err = To[uint]("-1")
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	f, err := To[float32](json.Number("1.5"))
+	assert.NoError(t, err)
+	assert.Equal(t, float32(1.5), f)
+
+	_, err = To[float32](1e300)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	s, err := To[string](1.25)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.25", s)
+
+	target, err := To[Target]("fb:1:2")
+	assert.NoError(t, err)
+	assert.Equal(t, Target("fb:1:2"), target)
+
+	b, err := To[bool]("true")
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	b, err = To[bool](0)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	_, err = To[bool]("maybe")
+	assert.True(t, errors.Is(err, ErrSyntax))
+}
+
+func TestToTime(t *testing.T) {
+	want := time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC)
+
+	tm, err := To[time.Time]("2021-06-01T12:30:00Z")
+	assert.NoError(t, err)
+	assert.True(t, want.Equal(tm))
+
+	tm, err = To[time.Time]("2021-06-01 12:30:00")
+	assert.NoError(t, err)
+	assert.True(t, want.Equal(tm))
+
+	tm, err = To[time.Time](want.Unix())
+	assert.NoError(t, err)
+	assert.True(t, want.Equal(tm))
+
+	_, err = To[time.Time]("yesterday")
+	assert.True(t, errors.Is(err, ErrSyntax))
+
+	d, err := To[time.Duration]("1m30s")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second, d)
+
+	d, err = To[time.Duration](int64(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, d)
+
+	s, err := To[string](90 * time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "1m30s", s)
+}
+
+func TestToCollections(t *testing.T) {
+	ints, err := To[[]int]([]any{1.0, "2", json.Number("3")})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ints)
+
+	_, err = To[[]int]([]any{1, "two"})
+	assert.True(t, errors.Is(err, ErrSyntax))
+	assert.Contains(t, err.Error(), "[1]")
+
+	m, err := To[map[string][]string](map[string]any{"tags": []any{"a", 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"tags": {"a", "1"}}, m)
+
+	durations, err := To[map[string]time.Duration](map[string]string{"timeout": "5s"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"timeout": 5 * time.Second}, durations)
+
+	_, err = To[[]int]("1,2")
+	assert.True(t, errors.Is(err, ErrWrongType))
+}
+
+func TestMustTo(t *testing.T) {
+	assert.Equal(t, 42, MustTo[int]("42"))
+	assert.Panics(t, func() {
+		MustTo[int]("forty two")
+	})
+}
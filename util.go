@@ -2,12 +2,11 @@ package utility
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
-	"strconv"
+	"reflect"
 	"strings"
 
 	uuid "github.com/satori/go.uuid"
@@ -15,10 +14,12 @@ import (
 	"github.com/unrolled/render"
 )
 
-// Log ...
-var Log *logrus.Entry
+// Log ... It is the logger of the default Service, set by SetupService.
+// Assigning it directly makes the package functions log to it instead.
+var Log = std.logrusEntry()
 
-// R ...
+// R ... It is the renderer of the default Service, set by SetupService.
+// Assigning it directly makes the package functions render with it instead.
 var R *render.Render
 
 // Target ...
@@ -42,17 +43,14 @@ const (
 	TargetWhatsAppPrefix = "whatsapp"
 )
 
-// SetupService ...
+// SetupService ... It makes a Service with log and r the default, see SetDefault.
 func SetupService(log *logrus.Entry, r *render.Render) {
-	Log = log
-	R = r
+	SetDefault(NewService(log, r, Options{}))
 }
 
 // ReadAll read and send the body in []byte form...
 func ReadAll(body io.ReadCloser) []byte {
-	b, e := ioutil.ReadAll(body)
-	PrintError(e)
-	return b
+	return Default().ReadAll(body)
 }
 
 // NopCloser returns a ReadCloser with a no-op
@@ -63,73 +61,52 @@ func NopCloser(body []byte) io.ReadCloser {
 
 // Panic check and panic if needed...
 func Panic(e error) {
-	if e != nil {
-		Log.Panic(e)
-	}
+	Default().Panic(e)
 }
 
 // PrintError ...
 func PrintError(e error) {
-	if e != nil {
-		Log.Println(e)
-	}
+	Default().PrintError(e)
 }
 
 // Print ...
 func Print(str interface{}) {
-	Log.Print(str)
+	Default().Print(str)
 }
 
-// Unmarshal ...
+// Unmarshal ... Use UnmarshalE to get the error.
 func Unmarshal(body []byte, v interface{}) interface{} {
-	PrintError(json.Unmarshal(body, v))
-	return v
+	return Default().Unmarshal(body, v)
 }
 
-// Marshal ...
+// Marshal ... Use MarshalE to get the error.
 func Marshal(v interface{}) []byte {
-	data, err := json.Marshal(v)
-	PrintError(err)
-	return data
+	return Default().Marshal(v)
 }
 
 // ParseForm ..
 func ParseForm(r *http.Request) {
-	PrintError(r.ParseForm())
+	Default().ParseForm(r)
 }
 
-// Int64 ...
+// Int64 ... Use ParseInt64 to get the error.
 func Int64(v string) int64 {
-	if IsBlank(v) {
-		return 0
-	}
-	intV, err := strconv.ParseInt(v, 10, 0)
-	PrintError(err)
-	return intV
+	return Default().Int64(v)
 }
 
-// Int ...
+// Int ... Use ParseInt to get the error.
 func Int(v string) int {
-	if IsBlank(v) {
-		return 0
-	}
-	return int(Int64(v))
+	return Default().Int(v)
 }
 
-// Float642Int ...
+// Float642Int ... Use FloatAsInt to get the error.
 func Float642Int(v interface{}) int {
-	if IsBlank(v) {
-		return 0
-	}
-	return int(Float64(v))
+	return Default().Float642Int(v)
 }
 
-// Float64 ...
+// Float64 ... Use AsFloat64 to get the error.
 func Float64(v interface{}) float64 {
-	if IsBlank(v) {
-		return 0
-	}
-	return v.(float64)
+	return Default().Float64(v)
 }
 
 // ToString ...
@@ -140,12 +117,9 @@ func ToString(v interface{}) string {
 	return fmt.Sprintf("%v", v)
 }
 
-// ToInt ...
+// ToInt ... Use AsInt to get the error.
 func ToInt(v interface{}) int {
-	if IsBlank(v) {
-		return 0
-	}
-	return v.(int)
+	return Default().ToInt(v)
 }
 
 // CleanPhone ...
@@ -163,7 +137,8 @@ func CleanPhone(v interface{}) string {
 	return phone
 }
 
-// PhoneValid ...
+// PhoneValid ... reports whether v is a valid number once cleaned, assuming the US when it has no country code.
+// Use ValidatePhone for the reason a number is invalid.
 func PhoneValid(v interface{}) bool {
 	if IsBlank(v) {
 		return false
@@ -171,24 +146,107 @@ func PhoneValid(v interface{}) bool {
 	if len(ToString(v)) < 10 {
 		return false
 	}
-	return true
+	return ValidatePhone(E164Phone(CleanPhone(v))).Valid()
 }
 
-// IsBlank ...
+// IsBlank reports whether v holds no meaningful value:
+//
+//   - nil, and typed nil pointers, interfaces, maps, slices, channels and funcs
+//   - the empty string (see IsBlankOrSpace for whitespace-only strings)
+//   - zero of every integer, float and complex kind
+//   - empty maps, slices and arrays
+//   - values with an IsZero() bool method that returns true, e.g. a zero time.Time
+//
+// Pointers and interfaces are followed, so a *string pointing to "" is blank.
+// Bools and structs without an IsZero method are never blank: false and an
+// empty struct are values in their own right. Use IsZero for Go zero values.
 func IsBlank(v interface{}) bool {
-	if v == 0 {
+	switch s := v.(type) {
+	case nil:
 		return true
+	case string:
+		return s == ""
+	case int:
+		return s == 0
+	case float64:
+		return s == 0
 	}
-	if v == "" {
+	return isBlank(reflect.ValueOf(v), false)
+}
+
+// IsBlankOrSpace is IsBlank, but also treats strings of only whitespace as blank.
+func IsBlankOrSpace(v interface{}) bool {
+	return isBlank(reflect.ValueOf(v), true)
+}
+
+// zeroer is implemented by types that know their zero value, like time.Time.
+type zeroer interface {
+	IsZero() bool
+}
+
+func isBlank(rv reflect.Value, space bool) bool {
+	if !rv.IsValid() {
 		return true
 	}
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		if rv.IsNil() {
+			return true
+		}
+	}
+	if z, ok := asZeroer(rv); ok {
+		return z.IsZero()
+	}
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return isBlank(rv.Elem(), space)
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return rv.Len() == 0
+	case reflect.String:
+		if space {
+			return strings.TrimSpace(rv.String()) == ""
+		}
+		return rv.Len() == 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	case reflect.Complex64, reflect.Complex128:
+		return rv.Complex() == 0
+	}
+	return false
+}
+
+// IsZero reports whether v is the zero value of its type. Typed nils are
+// zero, and types with an IsZero() bool method decide for themselves.
+func IsZero(v interface{}) bool {
 	if v == nil {
 		return true
 	}
-	return false
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		if rv.IsNil() {
+			return true
+		}
+	}
+	if z, ok := asZeroer(rv); ok {
+		return z.IsZero()
+	}
+	return rv.IsZero()
+}
+
+func asZeroer(rv reflect.Value) (zeroer, bool) {
+	if !rv.CanInterface() {
+		return nil, false
+	}
+	z, ok := rv.Interface().(zeroer)
+	return z, ok
 }
 
-// E164Phone ...
+// E164Phone ... US only, returns the digits without "+". Use NormalizePhone for international numbers.
 func E164Phone(v interface{}) string {
 	if IsBlank(v) {
 		return ""
@@ -205,33 +263,24 @@ func E164Phone(v interface{}) string {
 }
 
 // FormatPhone ... The Phone should have US country code in it. For ex., 19700000987 => +1 (970) 000-0987
+// Numbers of other regions need a leading "+", anything that can't be parsed is returned unchanged.
 func FormatPhone(v string) string {
-	// if len(v) > 11 {
-	//  return v
-	// }
-	//  p := Split(v, "")
-	//phone := fmt.Sprintf("+1 (%v%v%v) %v%v%v-%v%v%v%v", p[1], p[2], p[3], p[4], p[5], p[6], p[7], p[8], p[9], p[10])
-	return v
+	return Default().FormatPhone(v)
 }
 
-// ConvertMap ...
+// ConvertMap ... Use AsMap to get the error.
 func ConvertMap(v interface{}) map[string]interface{} {
-	if IsBlank(v) {
-		return nil
-	}
-	return v.(map[string]interface{})
+	return Default().ConvertMap(v)
 }
 
-// JSON2Map ...
-func JSON2Map(v interface{}) map[string]interface{} {
-	if IsBlank(v) {
-		return nil
-	}
-	rMsg := v.(json.RawMessage)
-	mapp := map[string]interface{}{}
-	e := json.Unmarshal(rMsg, &mapp)
-	PrintError(e)
-	return mapp
+// JSON2Map ... Use ParseJSONMap to get the error.
+func JSON2Map(v interface{}, opts ...JSONOption) map[string]interface{} {
+	return Default().JSON2Map(v, opts...)
+}
+
+// JSON2Slice ... Use ParseJSONSlice to get the error.
+func JSON2Slice(v interface{}, opts ...JSONOption) []interface{} {
+	return Default().JSON2Slice(v, opts...)
 }
 
 // Split ...
@@ -251,36 +300,15 @@ func UUID() string {
 	return uuid.NewV4().String()
 }
 
-// Origin Message Origin
+// Origin Message Origin, the display name of the channel the target belongs to.
 func Origin(target string) string {
-	targetArr := strings.Split(target, ":")
-	origin := targetArr[0]
-	switch origin {
-	case TargetHeymarketPrefix:
-		return "Heymarket"
-	case TargetAbcPrefix:
-		return "Apple Business Chat"
-	case TargetFacebookPrefix:
-		return "Facebook"
-	case TargetGmbPrefix:
-		return "Google"
-	case TargetLinePrefix:
-		return "Line"
-	case TargetWhatsAppPrefix:
-		return "WhatsApp"
-	}
-	return "SMS"
+	return ChannelFor(target).Name
 }
 
 // ShowPhone this will allow the message to show the phone number or hide the details if not sent via SMS.
+// WhatsApp is the exception: its conversations are keyed by the customer's phone number, so it is shown too.
 func ShowPhone(target string) bool {
-	targetArr := strings.Split(target, ":")
-	origin := targetArr[0]
-	switch origin {
-	case TargetHeymarketPrefix, TargetAbcPrefix, TargetFacebookPrefix, TargetGmbPrefix, TargetLinePrefix:
-		return false
-	}
-	return true
+	return ChannelFor(target).ShowPhone
 }
 
 // GetStringInBetween Returns empty string if no start string found
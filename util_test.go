@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -60,30 +61,26 @@ func TestInt(t *testing.T) {
 			},
 			wantErr: false,
 		},
-		// function failes on below 2 test-cases and gives : nil pointer dereference
-		// @fail : test-case
-		// {
-		//  name: "string with non numeric",
-		//  args: args{
-		//      input: "xyz1000",
-		//  },
-		//  want: want{
-		//      output: 0,
-		//  },
-		//  wantErr: false,
-		// },
-
-		// @fail : test-case
-		// {
-		//  name: "string with special chars",
-		//  args: args{
-		//      input: "#@%^&*",
-		//  },
-		//  want: want{
-		//      output: 0,
-		//  },
-		//  wantErr: false,
-		// },
+		{
+			name: "string with non numeric",
+			args: args{
+				input: "xyz1000",
+			},
+			want: want{
+				output: 0,
+			},
+			wantErr: false,
+		},
+		{
+			name: "string with special chars",
+			args: args{
+				input: "#@%^&*",
+			},
+			want: want{
+				output: 0,
+			},
+			wantErr: false,
+		},
 	}
 
 	// execute all the test cases
@@ -584,6 +581,9 @@ func TestCleanPhone(t *testing.T) {
 }
 
 func TestIsBlank(t *testing.T) {
+	var nilString *string
+	emptyString := ""
+
 	type args struct {
 		param interface{}
 	}
@@ -623,6 +623,141 @@ func TestIsBlank(t *testing.T) {
 				isBlank: true,
 			},
 		},
+		{
+			name: "For Int64 Zero",
+			args: args{
+				param: int64(0),
+			},
+			want: want{
+				isBlank: true,
+			},
+		},
+		{
+			name: "For Float Zero",
+			args: args{
+				param: 0.0,
+			},
+			want: want{
+				isBlank: true,
+			},
+		},
+		{
+			name: "For Uint8 Zero",
+			args: args{
+				param: uint8(0),
+			},
+			want: want{
+				isBlank: true,
+			},
+		},
+		{
+			name: "For Non Zero Int64",
+			args: args{
+				param: int64(7),
+			},
+			want: want{
+				isBlank: false,
+			},
+		},
+		{
+			name: "For Nil String Pointer",
+			args: args{
+				param: nilString,
+			},
+			want: want{
+				isBlank: true,
+			},
+		},
+		{
+			name: "For Pointer To Empty String",
+			args: args{
+				param: &emptyString,
+			},
+			want: want{
+				isBlank: true,
+			},
+		},
+		{
+			name: "For Empty Slice",
+			args: args{
+				param: []string{},
+			},
+			want: want{
+				isBlank: true,
+			},
+		},
+		{
+			name: "For Nil Map",
+			args: args{
+				param: map[string]interface{}(nil),
+			},
+			want: want{
+				isBlank: true,
+			},
+		},
+		{
+			name: "For Empty Prop",
+			args: args{
+				param: Prop{},
+			},
+			want: want{
+				isBlank: true,
+			},
+		},
+		{
+			name: "For Non Empty Slice",
+			args: args{
+				param: []int{0},
+			},
+			want: want{
+				isBlank: false,
+			},
+		},
+		{
+			name: "For Zero Time",
+			args: args{
+				param: time.Time{},
+			},
+			want: want{
+				isBlank: true,
+			},
+		},
+		{
+			name: "For Time",
+			args: args{
+				param: time.Now(),
+			},
+			want: want{
+				isBlank: false,
+			},
+		},
+		{
+			name: "For Whitespace",
+			args: args{
+				param: " ",
+			},
+			want: want{
+				isBlank: false,
+			},
+		},
+		{
+			name: "For False",
+			args: args{
+				param: false,
+			},
+			want: want{
+				isBlank: false,
+			},
+		},
+		{
+			name: "For Empty Struct",
+			args: args{
+				param: struct{}{},
+			},
+			want: want{
+				isBlank: false,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -633,6 +768,134 @@ func TestIsBlank(t *testing.T) {
 	}
 }
 
+func TestIsBlankOrSpace(t *testing.T) {
+	type args struct {
+		param interface{}
+	}
+	type want struct {
+		isBlank bool
+	}
+
+	space := " \t\n"
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "For Whitespace",
+			args: args{
+				param: space,
+			},
+			want: want{
+				isBlank: true,
+			},
+		},
+		{
+			name: "For Pointer To Whitespace",
+			args: args{
+				param: &space,
+			},
+			want: want{
+				isBlank: true,
+			},
+		},
+		{
+			name: "For Padded String",
+			args: args{
+				param: " Hello ",
+			},
+			want: want{
+				isBlank: false,
+			},
+		},
+		{
+			name: "For Zero",
+			args: args{
+				param: 0,
+			},
+			want: want{
+				isBlank: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output := IsBlankOrSpace(tc.args.param)
+			assert.Equal(t, tc.want.isBlank, output)
+		})
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	type args struct {
+		param interface{}
+	}
+	type want struct {
+		isZero bool
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "For False",
+			args: args{
+				param: false,
+			},
+			want: want{
+				isZero: true,
+			},
+		},
+		{
+			name: "For Zero Struct",
+			args: args{
+				param: struct{ Name string }{},
+			},
+			want: want{
+				isZero: true,
+			},
+		},
+		{
+			name: "For Struct",
+			args: args{
+				param: struct{ Name string }{Name: "Hey"},
+			},
+			want: want{
+				isZero: false,
+			},
+		},
+		{
+			name: "For Empty Non Nil Slice",
+			args: args{
+				param: []int{},
+			},
+			want: want{
+				isZero: false,
+			},
+		},
+		{
+			name: "For Zero Time In Other Zone",
+			args: args{
+				param: time.Time{}.In(time.FixedZone("X", 3600)),
+			},
+			want: want{
+				isZero: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output := IsZero(tc.args.param)
+			assert.Equal(t, tc.want.isZero, output)
+		})
+	}
+}
+
 func TestPhoneValid(t *testing.T) {
 	type args struct {
 		v interface{}
@@ -691,6 +954,60 @@ func TestPhoneValid(t *testing.T) {
 				output: true,
 			},
 		},
+		{
+			name: "10 letters",
+			args: args{
+				v: "abcdefghij",
+			},
+			want: want{
+				output: false,
+			},
+		},
+		{
+			name: "only brackets",
+			args: args{
+				v: "(((((((((((",
+			},
+			want: want{
+				output: false,
+			},
+		},
+		{
+			name: "formatted UK number",
+			args: args{
+				v: "+44 20 7946 0018",
+			},
+			want: want{
+				output: true,
+			},
+		},
+		{
+			name: "UK number with missing digits",
+			args: args{
+				v: "+44 20 7946",
+			},
+			want: want{
+				output: false,
+			},
+		},
+		{
+			name: "all zeros",
+			args: args{
+				v: "0000000000",
+			},
+			want: want{
+				output: false,
+			},
+		},
+		{
+			name: "US area code starting with 1",
+			args: args{
+				v: "1234567890",
+			},
+			want: want{
+				output: false,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1153,6 +1470,13 @@ func TestJSON2Map(t *testing.T) {
 	}
 }
 
+func TestJSON2Slice(t *testing.T) {
+	assert.Nil(t, JSON2Slice(nil))
+	assert.Nil(t, JSON2Slice(""))
+	assert.Equal(t, []interface{}{"a", 1.0}, JSON2Slice(`["a",1]`))
+	assert.Equal(t, []interface{}{json.Number("1")}, JSON2Slice([]byte(`[1]`), UseNumber()))
+}
+
 func TestUUID(t *testing.T) {
 
 	testCases := []struct {
@@ -1239,3 +1563,71 @@ func TestNopCloser(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatPhone(t *testing.T) {
+	type args struct {
+		v string
+	}
+	type want struct {
+		output string
+	}
+
+	testCases := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "US With Country Code",
+			args: args{
+				v: "19700000987",
+			},
+			want: want{
+				output: "+1 (970) 000-0987",
+			},
+		},
+		{
+			name: "US Without Country Code",
+			args: args{
+				v: "9700000987",
+			},
+			want: want{
+				output: "+1 (970) 000-0987",
+			},
+		},
+		{
+			name: "UK International",
+			args: args{
+				v: "+447911123456",
+			},
+			want: want{
+				output: "+44 7911 123456",
+			},
+		},
+		{
+			name: "Not A Phone",
+			args: args{
+				v: "hello",
+			},
+			want: want{
+				output: "hello",
+			},
+		},
+		{
+			name: "Foreign Number Without Plus",
+			args: args{
+				v: "44 20 7946 0018",
+			},
+			want: want{
+				output: "44 20 7946 0018",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output := FormatPhone(tc.args.v)
+			assert.Equal(t, tc.want.output, output)
+		})
+	}
+}